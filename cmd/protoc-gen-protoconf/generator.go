@@ -0,0 +1,216 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/gosynergy/protoconf"
+	"github.com/gosynergy/protoconf/flags"
+	"github.com/gosynergy/protoconf/internal/schema"
+	"github.com/gosynergy/protoconf/provider/env"
+)
+
+const (
+	contextPackage   = protogen.GoImportPath("context")
+	flagPackage      = protogen.GoImportPath("flag")
+	protoconfPackage = protogen.GoImportPath("github.com/gosynergy/protoconf")
+	envPackage       = protogen.GoImportPath("github.com/gosynergy/protoconf/provider/env")
+	flagsPackage     = protogen.GoImportPath("github.com/gosynergy/protoconf/flags")
+)
+
+// generate generates a `_protoconf.pb.go` file for every file declaring
+// configuration messages.
+func generate(plugin *protogen.Plugin) error {
+	plugin.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+
+	for _, file := range plugin.Files {
+		if !file.Generate {
+			continue
+		}
+
+		messages := configMessages(file.Messages)
+		if len(messages) == 0 {
+			continue
+		}
+
+		err := generateFile(plugin, file, messages)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// configMessages returns the configuration messages, including the nested ones.
+func configMessages(messages []*protogen.Message) []*protogen.Message {
+	var result []*protogen.Message
+
+	for _, message := range messages {
+		if schema.ConfigOptions(message.Desc) != nil {
+			result = append(result, message)
+		}
+
+		result = append(result, configMessages(message.Messages)...)
+	}
+
+	return result
+}
+
+func generateFile(plugin *protogen.Plugin, file *protogen.File, messages []*protogen.Message) error {
+	g := plugin.NewGeneratedFile(file.GeneratedFilenamePrefix+"_protoconf.pb.go", file.GoImportPath)
+
+	g.P("// Code generated by protoc-gen-protoconf. DO NOT EDIT.")
+	g.P("// source: ", file.Desc.Path())
+	g.P()
+	g.P("package ", file.GoPackageName)
+
+	for _, message := range messages {
+		err := generateMessage(g, message)
+		if err != nil {
+			return fmt.Errorf("%s: %w", message.Desc.FullName(), err)
+		}
+	}
+
+	return nil
+}
+
+func generateMessage(g *protogen.GeneratedFile, message *protogen.Message) error {
+	// The defaults are checked here, so the generated code cannot fail
+	// to apply them.
+	err := protoconf.CheckDefaults(message.Desc)
+	if err != nil {
+		return fmt.Errorf("invalid default: %w", err)
+	}
+
+	configOpts := schema.ConfigOptions(message.Desc)
+
+	generateLoad(g, message)
+	generateWatch(g, message)
+	generateDefaults(g, message)
+	generateEnv(g, message, configOpts.GetEnvPrefix())
+	generateFlags(g, message, configOpts.GetFlagPrefix())
+
+	return nil
+}
+
+func generateLoad(g *protogen.GeneratedFile, message *protogen.Message) {
+	name := message.GoIdent.GoName
+	newTyped := g.QualifiedGoIdent(protoconfPackage.Ident("NewTyped"))
+
+	g.P()
+	g.P("// Load", name, " loads, scans and validates a ", name, " from the providers")
+	g.P("// of the options.")
+	g.P("func Load", name, "(ctx ", contextPackage.Ident("Context"), ", opts ...",
+		protoconfPackage.Ident("Option"), ") (*", name, ", error) {")
+	g.P("loader, err := ", newTyped, "[*", name, "](opts...)")
+	g.P("if err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P()
+	g.P("err = loader.Load(ctx)")
+	g.P("if err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P()
+	g.P("return loader.Current(), nil")
+	g.P("}")
+}
+
+func generateWatch(g *protogen.GeneratedFile, message *protogen.Message) {
+	name := message.GoIdent.GoName
+	newTyped := g.QualifiedGoIdent(protoconfPackage.Ident("NewTyped"))
+	typedLoader := g.QualifiedGoIdent(protoconfPackage.Ident("TypedLoader"))
+
+	g.P()
+	g.P("// Watch", name, " loads a ", name, " and watches the providers of the options.")
+	g.P("// fn is called with the previous and the new ", name, " on every valid change.")
+	g.P("func Watch", name, "(ctx ", contextPackage.Ident("Context"), ", fn func(old", name, ", new", name,
+		" *", name, "), opts ...", protoconfPackage.Ident("Option"), ") (*", typedLoader, "[*", name, "], error) {")
+	g.P("loader, err := ", newTyped, "[*", name, "](opts...)")
+	g.P("if err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P()
+	g.P("err = loader.Load(ctx)")
+	g.P("if err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P()
+	g.P("loader.OnChange(fn)")
+	g.P()
+	g.P("err = loader.Watch()")
+	g.P("if err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P()
+	g.P("return loader, nil")
+	g.P("}")
+}
+
+func generateDefaults(g *protogen.GeneratedFile, message *protogen.Message) {
+	name := message.GoIdent.GoName
+
+	g.P()
+	g.P("// ", name, "Defaults returns a new ", name, " with the field defaults.")
+	g.P("func ", name, "Defaults() *", name, " {")
+	g.P("message := &", name, "{}")
+	g.P()
+	g.P("err := ", protoconfPackage.Ident("ApplyDefaults"), "(message)")
+	g.P("if err != nil {")
+	g.P("// The defaults are checked by protoc-gen-protoconf.")
+	g.P("panic(err)")
+	g.P("}")
+	g.P()
+	g.P("return message")
+	g.P("}")
+}
+
+func generateEnv(g *protogen.GeneratedFile, message *protogen.Message, prefix string) {
+	name := message.GoIdent.GoName
+
+	g.P()
+	g.P("// ", name, "EnvBindings are the environment variables bound to the ", name, " fields.")
+	g.P("var ", name, "EnvBindings = []", envPackage.Ident("Binding"), "{")
+
+	for _, binding := range env.Provider(message.Desc, env.WithPrefix(prefix)).Bindings() {
+		g.P("{Name: ", fmt.Sprintf("%q", binding.Name), ", Path: ", fmt.Sprintf("%q", binding.Path), "},")
+	}
+
+	g.P("}")
+	g.P()
+	g.P("// ", name, "Env returns the env provider of the ", name, " fields.")
+	g.P("func ", name, "Env(opts ...", envPackage.Ident("Option"), ") *", envPackage.Ident("Env"), " {")
+	g.P("opts = append([]", envPackage.Ident("Option"), "{", envPackage.Ident("WithPrefix"), "(",
+		fmt.Sprintf("%q", prefix), ")}, opts...)")
+	g.P()
+	g.P("return ", envPackage.Ident("Provider"), "((&", name, "{}).ProtoReflect().Descriptor(), opts...)")
+	g.P("}")
+}
+
+func generateFlags(g *protogen.GeneratedFile, message *protogen.Message, prefix string) {
+	name := message.GoIdent.GoName
+	fs := flag.NewFlagSet(string(message.Desc.FullName()), flag.ContinueOnError)
+
+	g.P()
+	g.P("// ", name, "FlagBindings are the command-line flags bound to the ", name, " fields.")
+	g.P("var ", name, "FlagBindings = []", flagsPackage.Ident("Binding"), "{")
+
+	for _, binding := range flags.Register(fs, message.Desc, flags.WithPrefix(prefix)).Bindings() {
+		g.P("{Name: ", fmt.Sprintf("%q", binding.Name), ", Path: ", fmt.Sprintf("%q", binding.Path), "},")
+	}
+
+	g.P("}")
+	g.P()
+	g.P("// Register", name, "Flags registers the flags of the ", name, " fields on fs.")
+	g.P("func Register", name, "Flags(fs *", flagPackage.Ident("FlagSet"), ", opts ...",
+		flagsPackage.Ident("Option"), ") *", flagsPackage.Ident("Flags"), " {")
+	g.P("opts = append([]", flagsPackage.Ident("Option"), "{", flagsPackage.Ident("WithPrefix"), "(",
+		fmt.Sprintf("%q", prefix), ")}, opts...)")
+	g.P()
+	g.P("return ", flagsPackage.Ident("Register"), "(fs, (&", name, "{}).ProtoReflect().Descriptor(), opts...)")
+	g.P("}")
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/gosynergy/protoconf"
+	"github.com/gosynergy/protoconf/cmd/protoc-gen-protoconf/internal/example"
+	protoconfpb "github.com/gosynergy/protoconf/protoconf"
+	"github.com/gosynergy/protoconf/provider/file"
+)
+
+const exampleFile = "cmd/protoc-gen-protoconf/internal/example/example_protoconf.pb.go"
+
+// request returns the plugin request of the file with its dependencies.
+func request(file protoreflect.FileDescriptor) *pluginpb.CodeGeneratorRequest {
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{file.Path()},
+		Parameter:      proto.String("paths=source_relative"),
+	}
+
+	seen := make(map[string]bool)
+
+	var add func(file protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if seen[file.Path()] {
+			return
+		}
+
+		seen[file.Path()] = true
+
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+
+		req.ProtoFile = append(req.ProtoFile, protodesc.ToFileDescriptorProto(file))
+	}
+
+	add(file)
+
+	return req
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := protogen.Options{}.New(request(example.File_cmd_protoc_gen_protoconf_internal_example_example_proto))
+	require.NoError(t, err)
+	require.NoError(t, generate(plugin))
+
+	resp := plugin.Response()
+	require.Empty(t, resp.GetError())
+	require.Len(t, resp.GetFile(), 1)
+
+	expected, err := os.ReadFile("../../" + exampleFile)
+	require.NoError(t, err)
+
+	assert.Equal(t, exampleFile, resp.GetFile()[0].GetName())
+	assert.Equal(t, string(expected), resp.GetFile()[0].GetContent())
+}
+
+func TestGenerate_InvalidDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		parentDefault bool
+	}{
+		{
+			name:          "parent with default",
+			parentDefault: true,
+		},
+		{
+			name:          "parent without default",
+			parentDefault: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := request(example.File_cmd_protoc_gen_protoconf_internal_example_example_proto)
+
+			var fileProto *descriptorpb.FileDescriptorProto
+
+			for _, f := range req.GetProtoFile() {
+				if f.GetName() == req.GetFileToGenerate()[0] {
+					fileProto = f
+				}
+			}
+
+			config := fileProto.GetMessageType()[0]
+
+			// Config.server
+			if !test.parentDefault {
+				config.GetField()[0].Options = &descriptorpb.FieldOptions{}
+			}
+
+			// Config.Server.timeout
+			timeout := config.GetNestedType()[0].GetField()[1]
+			timeout.Options = &descriptorpb.FieldOptions{}
+			proto.SetExtension(timeout.GetOptions(), protoconfpb.E_Field, &protoconfpb.FieldOptions{Default: "soon"})
+
+			plugin, err := protogen.Options{}.New(req)
+			require.NoError(t, err)
+
+			err = generate(plugin)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "example.Config: invalid default")
+			assert.Contains(t, err.Error(), "example.Config.Server.timeout")
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	config, err := example.LoadConfig(context.Background(),
+		protoconf.WithProvider(file.Provider("testdata/config.yaml")),
+		protoconf.WithParser(yaml.Parser()),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, ":9090", config.GetServer().GetAddr())
+	assert.Equal(t, time.Second, config.GetServer().GetTimeout().AsDuration())
+	assert.Equal(t, []string{"a", "b"}, config.GetTags())
+}
+
+func TestConfigDefaults(t *testing.T) {
+	t.Parallel()
+
+	config := example.ConfigDefaults()
+
+	assert.Equal(t, ":8080", config.GetServer().GetAddr())
+	assert.Equal(t, time.Second, config.GetServer().GetTimeout().AsDuration())
+}
+
+func TestRegisterConfigFlags(t *testing.T) {
+	t.Parallel()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := example.RegisterConfigFlags(fs)
+
+	require.NoError(t, fs.Parse([]string{"--config.server.addr", ":7070"}))
+
+	values, err := flags.Read()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"server": map[string]interface{}{"addr": ":7070"}}, values)
+
+	for i, binding := range flags.Bindings() {
+		assert.Equal(t, example.ConfigFlagBindings[i].Name, binding.Name)
+		assert.Equal(t, example.ConfigFlagBindings[i].Path, binding.Path)
+	}
+}
@@ -0,0 +1,92 @@
+// Code generated by protoc-gen-protoconf. DO NOT EDIT.
+// source: cmd/protoc-gen-protoconf/internal/example/example.proto
+
+package example
+
+import (
+	context "context"
+	flag "flag"
+	protoconf "github.com/gosynergy/protoconf"
+	flags "github.com/gosynergy/protoconf/flags"
+	env "github.com/gosynergy/protoconf/provider/env"
+)
+
+// LoadConfig loads, scans and validates a Config from the providers
+// of the options.
+func LoadConfig(ctx context.Context, opts ...protoconf.Option) (*Config, error) {
+	loader, err := protoconf.NewTyped[*Config](opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = loader.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return loader.Current(), nil
+}
+
+// WatchConfig loads a Config and watches the providers of the options.
+// fn is called with the previous and the new Config on every valid change.
+func WatchConfig(ctx context.Context, fn func(oldConfig, newConfig *Config), opts ...protoconf.Option) (*protoconf.TypedLoader[*Config], error) {
+	loader, err := protoconf.NewTyped[*Config](opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = loader.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loader.OnChange(fn)
+
+	err = loader.Watch()
+	if err != nil {
+		return nil, err
+	}
+
+	return loader, nil
+}
+
+// ConfigDefaults returns a new Config with the field defaults.
+func ConfigDefaults() *Config {
+	message := &Config{}
+
+	err := protoconf.ApplyDefaults(message)
+	if err != nil {
+		// The defaults are checked by protoc-gen-protoconf.
+		panic(err)
+	}
+
+	return message
+}
+
+// ConfigEnvBindings are the environment variables bound to the Config fields.
+var ConfigEnvBindings = []env.Binding{
+	{Name: "EXAMPLE_SERVER_ADDR", Path: "server.addr"},
+	{Name: "EXAMPLE_SERVER_TIMEOUT", Path: "server.timeout"},
+	{Name: "EXAMPLE_TAGS", Path: "tags"},
+}
+
+// ConfigEnv returns the env provider of the Config fields.
+func ConfigEnv(opts ...env.Option) *env.Env {
+	opts = append([]env.Option{env.WithPrefix("EXAMPLE")}, opts...)
+
+	return env.Provider((&Config{}).ProtoReflect().Descriptor(), opts...)
+}
+
+// ConfigFlagBindings are the command-line flags bound to the Config fields.
+var ConfigFlagBindings = []flags.Binding{
+	{Name: "config.server.addr", Path: "server.addr"},
+	{Name: "config.server.timeout", Path: "server.timeout"},
+	{Name: "config.tags", Path: "tags"},
+}
+
+// RegisterConfigFlags registers the flags of the Config fields on fs.
+func RegisterConfigFlags(fs *flag.FlagSet, opts ...flags.Option) *flags.Flags {
+	opts = append([]flags.Option{flags.WithPrefix("config")}, opts...)
+
+	return flags.Register(fs, (&Config{}).ProtoReflect().Descriptor(), opts...)
+}
@@ -0,0 +1,32 @@
+// Command protoc-gen-protoconf is a protoc plugin generating the loader
+// helpers of the configuration messages, the messages marked with
+// the `(protoconf.config)` option.
+//
+// For a configuration message `Config` it generates:
+//
+//   - LoadConfig, loading a validated Config with a ConfigLoader;
+//   - WatchConfig, loading a Config and watching its providers;
+//   - ConfigDefaults, returning a Config with the field defaults;
+//   - ConfigEnvBindings and ConfigEnv, the environment variables bound to
+//     the fields and their provider;
+//   - ConfigFlagBindings and RegisterConfigFlags, the command-line flags
+//     bound to the fields and their registration.
+//
+// The plugin is used next to protoc-gen-go, e.g. in buf.gen.yaml:
+//
+//	plugins:
+//	  - plugin: go
+//	    out: .
+//	    opt: paths=source_relative
+//	  - plugin: protoconf
+//	    out: .
+//	    opt: paths=source_relative
+package main
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func main() {
+	protogen.Options{}.Run(generate)
+}
@@ -0,0 +1,469 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/gosynergy/protoconf"
+	"github.com/gosynergy/protoconf/docs"
+	"github.com/gosynergy/protoconf/flags"
+	"github.com/gosynergy/protoconf/jsonschema"
+	"github.com/gosynergy/protoconf/provider/env"
+)
+
+var (
+	errOutput   = errors.New("unknown output format")
+	errNotFound = errors.New("no value")
+)
+
+// validate reports every error of the configuration.
+func validate(args []string, _, stderr io.Writer) int {
+	var conf config
+
+	fs := newFlagSet("validate", "[flags] <file>...", stderr)
+	conf.register(fs)
+
+	if fs.Parse(args) != nil {
+		return exitUsage
+	}
+
+	files, desc, err := conf.messageDescriptor()
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	loader, err := conf.loader(desc, fs.Args(), protoconf.WithCollectErrors())
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	_, err = loader.ScanDynamic(files, desc.FullName())
+	if err == nil {
+		return exitOK
+	}
+
+	var multiErr *protoconf.MultiError
+	if !errors.As(err, &multiErr) {
+		return fail(stderr, err)
+	}
+
+	for _, configErr := range multiErr.Errors {
+		fmt.Fprintln(stderr, configErr)
+	}
+
+	return exitFailure
+}
+
+// render prints the effective configuration, with the transformers and
+// the defaults applied. The sensitive fields are redacted, unless
+// -show-sensitive is set.
+func render(args []string, stdout, stderr io.Writer) int {
+	var conf config
+
+	fs := newFlagSet("render", "[flags] <file>...", stderr)
+	conf.register(fs)
+	output := fs.String("output", "yaml", "output format: yaml or json")
+	showSensitive := fs.Bool("show-sensitive", false, "print the values of the sensitive fields")
+
+	if fs.Parse(args) != nil {
+		return exitUsage
+	}
+
+	message, _, err := conf.scan(fs.Args())
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	var printed proto.Message = message
+	if !*showSensitive {
+		printed = protoconf.Redact(message)
+	}
+
+	data, err := marshal(printed, *output)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	_, err = stdout.Write(data)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	return exitOK
+}
+
+// explain prints the origin of the value at the path, or the origins of
+// the values under a message path.
+func explain(args []string, stdout, stderr io.Writer) int {
+	var conf config
+
+	fs := newFlagSet("explain", "[flags] <path> <file>...", stderr)
+	conf.register(fs)
+
+	if fs.Parse(args) != nil {
+		return exitUsage
+	}
+
+	if fs.NArg() < 1 {
+		fs.Usage()
+
+		return exitUsage
+	}
+
+	path := fs.Arg(0)
+
+	// Explain only needs the loaded values, so it also works on the
+	// configurations which do not validate.
+	_, desc, err := conf.messageDescriptor()
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	loader, err := conf.loader(desc, fs.Args()[1:])
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	origins := loader.ExplainAll(path)
+	if len(origins) == 0 {
+		return fail(stderr, fmt.Errorf("%w at %q", errNotFound, path))
+	}
+
+	origin, ok := origins[path]
+	if ok && len(origins) == 1 {
+		fmt.Fprintln(stdout, origin)
+
+		return exitOK
+	}
+
+	// A message path: the origins of every leaf under it.
+	paths := make([]string, 0, len(origins))
+	for leafPath := range origins {
+		paths = append(paths, leafPath)
+	}
+
+	sort.Strings(paths)
+
+	for _, leafPath := range paths {
+		fmt.Fprintf(stdout, "%s: %s\n", leafPath, origins[leafPath])
+	}
+
+	return exitOK
+}
+
+// diff prints the values which differ between two configurations. It
+// exits with the failure code when the configurations differ. The
+// sensitive fields are redacted, unless -show-sensitive is set.
+func diff(args []string, stdout, stderr io.Writer) int {
+	var conf config
+
+	fs := newFlagSet("diff", "[flags] <file> <file>", stderr)
+	conf.register(fs)
+	showSensitive := fs.Bool("show-sensitive", false, "print the values of the sensitive fields")
+
+	if fs.Parse(args) != nil {
+		return exitUsage
+	}
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+
+		return exitUsage
+	}
+
+	leaves := make([]map[string]string, 0, fs.NArg())
+
+	for _, path := range fs.Args() {
+		message, _, err := conf.scan([]string{path})
+		if err != nil {
+			return fail(stderr, fmt.Errorf("%s: %w", path, err))
+		}
+
+		var compared proto.Message = message
+		if !*showSensitive {
+			compared = protoconf.Redact(message)
+		}
+
+		values, err := flatten(compared)
+		if err != nil {
+			return fail(stderr, err)
+		}
+
+		leaves = append(leaves, values)
+	}
+
+	changes := diffLeaves(leaves[0], leaves[1])
+	for _, change := range changes {
+		fmt.Fprintln(stdout, change)
+	}
+
+	if len(changes) > 0 {
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+// jsonSchema prints the JSON Schema of the configuration message.
+func jsonSchema(args []string, stdout, stderr io.Writer) int {
+	var conf config
+
+	fs := newFlagSet("schema", "[flags]", stderr)
+	conf.registerMessage(fs)
+	jsonNames := fs.Bool("json-names", false, "name the properties with the JSON names of the fields")
+	strict := fs.Bool("strict", false, "disallow the properties which are not fields of the message")
+
+	if fs.Parse(args) != nil {
+		return exitUsage
+	}
+
+	_, desc, err := conf.messageDescriptor()
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	var opts []jsonschema.Option
+
+	if *jsonNames {
+		opts = append(opts, jsonschema.WithJSONNames())
+	}
+
+	if *strict {
+		opts = append(opts, jsonschema.WithStrict())
+	}
+
+	data, err := json.MarshalIndent(jsonschema.Generate(desc, opts...), "", "  ")
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	_, err = stdout.Write(append(data, '\n'))
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	return exitOK
+}
+
+// docsCommand prints the reference documentation of the configuration message.
+func docsCommand(args []string, stdout, stderr io.Writer) int {
+	var conf config
+
+	fs := newFlagSet("docs", "[flags]", stderr)
+	conf.registerMessage(fs)
+	format := fs.String("format", "markdown", "output format: markdown or html")
+	withEnv := fs.Bool("env", false, "document the environment variables bound to the keys")
+	envPrefix := fs.String("env-prefix", "", "prefix of the environment variables, implies -env")
+	withFlags := fs.Bool("flags", false, "document the command-line flags bound to the keys")
+	flagPrefix := fs.String("flag-prefix", "", "prefix of the flag names, implies -flags")
+
+	if fs.Parse(args) != nil {
+		return exitUsage
+	}
+
+	_, desc, err := conf.messageDescriptor()
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	var opts []docs.Option
+
+	if *withEnv || *envPrefix != "" {
+		opts = append(opts, docs.WithEnv(env.WithPrefix(*envPrefix)))
+	}
+
+	if *withFlags || *flagPrefix != "" {
+		opts = append(opts, docs.WithFlags(flags.WithPrefix(*flagPrefix)))
+	}
+
+	message := docs.Generate(desc, opts...)
+
+	switch *format {
+	case "markdown":
+		err = message.Markdown(stdout)
+	case "html":
+		err = message.HTML(stdout)
+	default:
+		err = fmt.Errorf("%w %q", errOutput, *format)
+	}
+
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	return exitOK
+}
+
+func newFlagSet(name, args string, output io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.Usage = func() {
+		fmt.Fprintf(output, "Usage: protoconf %s %s\n\nFlags:\n", name, args)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+func fail(stderr io.Writer, err error) int {
+	fmt.Fprintf(stderr, "protoconf: %v\n", err)
+
+	return exitFailure
+}
+
+// marshal renders the message as YAML or JSON, keeping the field order.
+func marshal(message proto.Message, format string) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("protojson marshal: %w", err)
+	}
+
+	switch format {
+	case "json":
+		// protojson output is deliberately unstable, json.Indent is not.
+		var buf bytes.Buffer
+
+		err = json.Indent(&buf, data, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("json indent: %w", err)
+		}
+
+		buf.WriteByte('\n')
+
+		return buf.Bytes(), nil
+	case "yaml":
+		// JSON is YAML: decoding it into a node keeps the field order.
+		var node yamlv3.Node
+
+		err = yamlv3.Unmarshal(data, &node)
+		if err != nil {
+			return nil, fmt.Errorf("yaml unmarshal: %w", err)
+		}
+
+		resetStyle(&node)
+
+		var buf bytes.Buffer
+
+		encoder := yamlv3.NewEncoder(&buf)
+		encoder.SetIndent(2)
+
+		err = encoder.Encode(&node)
+		if err != nil {
+			return nil, fmt.Errorf("yaml marshal: %w", err)
+		}
+
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("%w %q", errOutput, format)
+	}
+}
+
+// resetStyle renders the nodes in the block style.
+func resetStyle(node *yamlv3.Node) {
+	node.Style = 0
+
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// flatten returns the JSON of the message leaves by dotted path.
+func flatten(message proto.Message) (map[string]string, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("protojson marshal: %w", err)
+	}
+
+	var values map[string]interface{}
+
+	err = json.Unmarshal(data, &values)
+	if err != nil {
+		return nil, fmt.Errorf("json unmarshal: %w", err)
+	}
+
+	leaves := make(map[string]string)
+
+	err = flattenInto(leaves, "", values)
+	if err != nil {
+		return nil, err
+	}
+
+	return leaves, nil
+}
+
+func flattenInto(leaves map[string]string, path string, values map[string]interface{}) error {
+	for key, value := range values {
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+
+		nested, ok := value.(map[string]interface{})
+		if ok && len(nested) > 0 {
+			err := flattenInto(leaves, keyPath, nested)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("json marshal %s: %w", keyPath, err)
+		}
+
+		leaves[keyPath] = string(data)
+	}
+
+	return nil
+}
+
+// diffLeaves returns the removed (-) and added (+) leaves by path.
+func diffLeaves(a, b map[string]string) []string {
+	paths := make(map[string]struct{}, len(a)+len(b))
+	for path := range a {
+		paths[path] = struct{}{}
+	}
+
+	for path := range b {
+		paths[path] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+
+	sort.Strings(sorted)
+
+	var changes []string
+
+	for _, path := range sorted {
+		oldValue, inA := a[path]
+		newValue, inB := b[path]
+
+		if inA && inB && oldValue == newValue {
+			continue
+		}
+
+		if inA {
+			changes = append(changes, "- "+path+": "+oldValue)
+		}
+
+		if inB {
+			changes = append(changes, "+ "+path+": "+newValue)
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/knadh/koanf/parsers/json"
+	"github.com/knadh/koanf/parsers/toml"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/gosynergy/protoconf"
+	"github.com/gosynergy/protoconf/parser/protobuf"
+	"github.com/gosynergy/protoconf/parser/sops"
+	"github.com/gosynergy/protoconf/parser/yaml"
+	"github.com/gosynergy/protoconf/provider/env"
+	"github.com/gosynergy/protoconf/provider/file"
+	"github.com/gosynergy/protoconf/transform/expandenv"
+	"github.com/gosynergy/protoconf/transform/secrets"
+)
+
+var (
+	errNoDescriptor = errors.New("-descriptor is required")
+	errNoMessage    = errors.New("-message is required")
+	errNoFiles      = errors.New("no configuration files")
+	errParser       = errors.New("unknown parser")
+	errBackend      = errors.New("invalid secrets backend, expected name=url")
+	errSOPSParser   = errors.New("-sops needs -parser or a known file extension")
+)
+
+// config holds the flags shared by the commands.
+type config struct {
+	descriptor string
+	message    string
+	parser     string
+	env        bool
+	envPrefix  string
+	expandEnv  bool
+	strict     bool
+	profiles   string
+	includes   bool
+
+	expandStrict   bool
+	expandAllow    string
+	expandPrefixes string
+	expandNoArith  bool
+
+	secrets        bool
+	secretBackends map[string]string
+
+	sops        bool
+	sopsKeyFile string
+}
+
+func (c *config) register(fs *flag.FlagSet) {
+	c.registerMessage(fs)
+	fs.StringVar(&c.parser, "parser", "", "parser of the configuration files: yaml, json, toml, prototext or pb (default by file extension or content)")
+	fs.BoolVar(&c.env, "env", false, "read the environment variables bound to the message fields")
+	fs.StringVar(&c.envPrefix, "env-prefix", "", "prefix of the environment variables, implies -env")
+	fs.BoolVar(&c.expandEnv, "expandenv", false, "expand the ${VAR} references in the values")
+	fs.BoolVar(&c.strict, "strict", false, "fail on the keys which are not fields of the message")
+	fs.StringVar(&c.profiles, "profiles", "", "comma separated active profiles, e.g. prod,eu-west")
+	fs.BoolVar(&c.includes, "includes", false, "resolve the $include and !include directives of the files")
+
+	fs.BoolVar(&c.expandStrict, "expandenv-strict", false, "fail on the unset variables without a default, implies -expandenv")
+	fs.StringVar(&c.expandAllow, "expandenv-allow", "", "comma separated variables which can be expanded, implies -expandenv")
+	fs.StringVar(&c.expandPrefixes, "expandenv-allow-prefix", "",
+		"comma separated prefixes of the variables which can be expanded, implies -expandenv")
+	fs.BoolVar(&c.expandNoArith, "expandenv-no-arith", false, "fail on the arithmetic expansions, implies -expandenv")
+
+	fs.BoolVar(&c.secrets, "secrets", false, "resolve the file:// and env:// secret references")
+	fs.Func("secrets-backend", "secret://name/... backend as name=url of an HTTP key-value store, implies -secrets",
+		func(s string) error {
+			name, url, ok := strings.Cut(s, "=")
+			if !ok || name == "" || url == "" {
+				return fmt.Errorf("%w: %q", errBackend, s)
+			}
+
+			if c.secretBackends == nil {
+				c.secretBackends = make(map[string]string)
+			}
+
+			c.secretBackends[name] = url
+
+			return nil
+		})
+
+	fs.BoolVar(&c.sops, "sops", false, "decrypt the SOPS files with the age keys of SOPS_AGE_KEY, SOPS_AGE_KEY_FILE "+
+		"or the sops/age/keys.txt file of the user configuration directory")
+	fs.StringVar(&c.sopsKeyFile, "sops-key-file", "", "age key file decrypting the SOPS files, implies -sops")
+}
+
+// registerMessage registers the flags selecting the configuration message.
+func (c *config) registerMessage(fs *flag.FlagSet) {
+	fs.StringVar(&c.descriptor, "descriptor", "", "compiled descriptor set file, e.g. the output of `buf build -o`")
+	fs.StringVar(&c.message, "message", "", "fully qualified name of the configuration message, e.g. conf.v1.Config")
+}
+
+// messageDescriptor reads the descriptor set and finds the message in it.
+func (c *config) messageDescriptor() (*protoregistry.Files, protoreflect.MessageDescriptor, error) {
+	if c.descriptor == "" {
+		return nil, nil, errNoDescriptor
+	}
+
+	if c.message == "" {
+		return nil, nil, errNoMessage
+	}
+
+	data, err := os.ReadFile(c.descriptor)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read descriptor set: %w", err)
+	}
+
+	var set descriptorpb.FileDescriptorSet
+
+	err = proto.Unmarshal(data, &set)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unmarshal descriptor set: %w", err)
+	}
+
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create files: %w", err)
+	}
+
+	desc, err := protoconf.FindMessage(files, protoreflect.FullName(c.message))
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	return files, desc, nil
+}
+
+// loader creates a loaded ConfigLoader reading the files in order.
+func (c *config) loader(
+	desc protoreflect.MessageDescriptor,
+	paths []string,
+	opts ...protoconf.Option,
+) (*protoconf.ConfigLoader, error) {
+	if len(paths) == 0 {
+		return nil, errNoFiles
+	}
+
+	for _, path := range paths {
+		parser, err := c.fileParser(path, desc)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.sops || c.sopsKeyFile != "" {
+			parser, err = c.sopsParser(parser)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		opts = append(opts, protoconf.WithLayer(file.Provider(path), parser))
+	}
+
+	if c.env || c.envPrefix != "" {
+		opts = append(opts, protoconf.WithLayer(env.Provider(desc, env.WithPrefix(c.envPrefix)), json.Parser()))
+	}
+
+	opts = append(opts, c.transformers()...)
+
+	if c.strict {
+		opts = append(opts, protoconf.WithStrict())
+	}
+
+	if c.includes {
+		opts = append(opts, protoconf.WithIncludes())
+	}
+
+	if c.profiles != "" {
+		opts = append(opts, protoconf.WithProfiles(strings.Split(c.profiles, ",")...))
+	}
+
+	opts = append(opts, protoconf.WithParserRegistry(registry(desc)))
+
+	loader, err := protoconf.New(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create loader: %w", err)
+	}
+
+	err = loader.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load: %w", err)
+	}
+
+	return loader, nil
+}
+
+// transformers returns the options of the transformers set by the flags,
+// the variables are expanded before the secrets are resolved.
+func (c *config) transformers() []protoconf.Option {
+	var opts []protoconf.Option
+
+	var expandOpts []expandenv.Option
+
+	if c.expandStrict {
+		expandOpts = append(expandOpts, expandenv.WithStrict())
+	}
+
+	if c.expandAllow != "" {
+		expandOpts = append(expandOpts, expandenv.WithAllowlist(strings.Split(c.expandAllow, ",")...))
+	}
+
+	if c.expandPrefixes != "" {
+		for _, prefix := range strings.Split(c.expandPrefixes, ",") {
+			expandOpts = append(expandOpts, expandenv.WithAllowedPrefix(prefix))
+		}
+	}
+
+	if c.expandNoArith {
+		expandOpts = append(expandOpts, expandenv.WithoutArithmetic())
+	}
+
+	if c.expandEnv || len(expandOpts) > 0 {
+		opts = append(opts, protoconf.WithTransformers(expandenv.NewTransformer(expandOpts...)))
+	}
+
+	if c.secrets || len(c.secretBackends) > 0 {
+		secretOpts := make([]secrets.Option, 0, len(c.secretBackends))
+		for name, url := range c.secretBackends {
+			secretOpts = append(secretOpts, secrets.WithBackend(name, secrets.NewHTTPResolver(url)))
+		}
+
+		opts = append(opts, protoconf.WithTransformers(secrets.NewTransformer(secretOpts...)))
+	}
+
+	return opts
+}
+
+// sopsParser returns the parser decrypting the SOPS files parsed by parser.
+func (c *config) sopsParser(parser protoconf.Parser) (protoconf.Parser, error) {
+	if parser == nil {
+		return nil, errSOPSParser
+	}
+
+	var opts []sops.Option
+
+	if c.sopsKeyFile != "" {
+		opts = append(opts, sops.WithKeyFile(c.sopsKeyFile))
+	}
+
+	return sops.Parser(parser, opts...), nil
+}
+
+// scan loads the files and scans them into a new message.
+func (c *config) scan(paths []string) (*dynamicpb.Message, *protoconf.ConfigLoader, error) {
+	files, desc, err := c.messageDescriptor()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	loader, err := c.loader(desc, paths)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	message, err := loader.ScanDynamic(files, desc.FullName())
+	if err != nil {
+		return nil, nil, fmt.Errorf("scan: %w", err)
+	}
+
+	return message, loader, nil
+}
+
+// fileParser returns the parser set by -parser, or the parser of the file
+// extension. The parser of the other files is detected by the loader.
+func (c *config) fileParser(path string, desc protoreflect.MessageDescriptor) (protoconf.Parser, error) {
+	switch c.parser {
+	case "":
+		parser, _ := registry(desc).ByExtension(path)
+
+		return parser, nil
+	case "yaml":
+		return yaml.Parser(), nil
+	case "json":
+		return json.Parser(), nil
+	case "toml":
+		return toml.Parser(), nil
+	case "prototext":
+		return protobuf.Text(desc), nil
+	case "pb":
+		return protobuf.Binary(desc), nil
+	default:
+		return nil, fmt.Errorf("%w %q", errParser, c.parser)
+	}
+}
+
+// registry returns the default registry with the YAML parser reporting
+// the positions and the protobuf parsers of the message.
+func registry(desc protoreflect.MessageDescriptor) *protoconf.Registry {
+	r := protoconf.DefaultRegistry()
+	r.Register(yaml.Parser(), protoconf.ExtYAML, ".yml")
+	protobuf.Register(r, desc)
+
+	return r
+}
@@ -0,0 +1,71 @@
+// Command protoconf validates, renders, explains and diffs configuration
+// files against a message of a compiled descriptor set and generates its
+// JSON Schema and reference documentation, e.g. the output
+// of `buf build -o config.binpb`.
+//
+// Usage:
+//
+//	protoconf validate -descriptor config.binpb -message conf.v1.Config conf/config.yaml
+//	protoconf render -descriptor config.binpb -message conf.v1.Config -output json conf/config.yaml
+//	protoconf explain -descriptor config.binpb -message conf.v1.Config server.http.addr conf/config.yaml
+//	protoconf diff -descriptor config.binpb -message conf.v1.Config conf/a.yaml conf/b.yaml
+//	protoconf schema -descriptor config.binpb -message conf.v1.Config > config.schema.json
+//	protoconf docs -descriptor config.binpb -message conf.v1.Config -env-prefix APP > CONFIG.md
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	exitOK = iota
+	exitFailure
+	exitUsage
+)
+
+const usage = `Usage: protoconf <command> [flags] [args]
+
+Commands:
+  validate  validate the configuration files
+  render    print the effective configuration
+  explain   print where a configuration value comes from
+  diff      print the differences between two configurations
+  schema    print the JSON Schema of the configuration message
+  docs      print the reference documentation of the configuration message
+
+Run 'protoconf <command> -h' for the command flags.
+`
+
+type command func(args []string, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"validate": validate,
+	"render":   render,
+	"explain":  explain,
+	"diff":     diff,
+	"schema":   jsonSchema,
+	"docs":     docsCommand,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+
+		return exitUsage
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "protoconf: unknown command %q\n\n%s", args[0], usage)
+
+		return exitUsage
+	}
+
+	return cmd(args[1:], stdout, stderr)
+}
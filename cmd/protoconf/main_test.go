@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const descriptor = "../../conf/config.binpb"
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			name:   "no command",
+			code:   exitUsage,
+			stderr: "Usage: protoconf <command>",
+		},
+		{
+			name:   "unknown command",
+			args:   []string{"lint"},
+			code:   exitUsage,
+			stderr: `unknown command "lint"`,
+		},
+		{
+			name: "validate",
+			args: []string{
+				"validate", "-descriptor", descriptor, "-message", "conf.v1.ConfigWithValidate",
+				"-expandenv", "../../conf/config.yaml",
+			},
+			code: exitOK,
+		},
+		{
+			name: "validate errors",
+			args: []string{
+				"validate", "-descriptor", descriptor, "-message", "conf.v1.ConfigWithValidate",
+				"-strict", "../../conf/multi-error-config.yaml",
+			},
+			code: exitFailure,
+			stderr: `../../conf/multi-error-config.yaml:6:13: server.grpc.timout: unknown field, did you mean "timeout"?
+../../conf/multi-error-config.yaml:3:14: server.http.timeout: invalid google.protobuf.Duration value "invalid"
+server.http.addr: value is required [required]
+`,
+		},
+		{
+			name:   "validate unknown message",
+			args:   []string{"validate", "-descriptor", descriptor, "-message", "conf.v1.Missing", "a.yaml"},
+			code:   exitFailure,
+			stderr: `find message "conf.v1.Missing"`,
+		},
+		{
+			name: "render json",
+			args: []string{
+				"render", "-descriptor", descriptor, "-message", "conf.v1.Config",
+				"-output", "json", "../../conf/config-overlay.json",
+			},
+			code: exitOK,
+			stdout: `{
+  "server": {
+    "http": {
+      "addr": "0.0.0.0:80",
+      "timeout": "1s"
+    }
+  },
+  "data": {
+    "redis": {
+      "read_timeout": "1s"
+    }
+  }
+}
+`,
+		},
+		{
+			name: "render yaml",
+			args: []string{
+				"render", "-descriptor", descriptor, "-message", "conf.v1.Config",
+				"../../conf/config-overlay.json",
+			},
+			code: exitOK,
+			stdout: `server:
+  http:
+    addr: 0.0.0.0:80
+    timeout: 1s
+data:
+  redis:
+    read_timeout: 1s
+`,
+		},
+		{
+			name: "render redacted",
+			args: []string{
+				"render", "-descriptor", descriptor, "-message", "conf.v1.Config.Data.Database",
+				"-output", "json", "../../conf/config-database.yaml",
+			},
+			code: exitOK,
+			stdout: `{
+  "driver": "mysql",
+  "source": "[REDACTED]"
+}
+`,
+		},
+		{
+			name: "render show sensitive",
+			args: []string{
+				"render", "-descriptor", descriptor, "-message", "conf.v1.Config.Data.Database",
+				"-output", "json", "-show-sensitive", "../../conf/config-database.yaml",
+			},
+			code: exitOK,
+			stdout: `{
+  "driver": "mysql",
+  "source": "root:root@tcp(127.0.0.1:3306)/test"
+}
+`,
+		},
+		{
+			name: "render toml",
+			args: []string{
+				"render", "-descriptor", descriptor, "-message", "conf.v1.Config.Server.Http",
+				"-output", "json", "../../conf/config-http.toml",
+			},
+			code: exitOK,
+			stdout: `{
+  "addr": "127.0.0.1:8080",
+  "timeout": "2s"
+}
+`,
+		},
+		{
+			name: "render prototext",
+			args: []string{
+				"render", "-descriptor", descriptor, "-message", "conf.v1.Config", "../../conf/config.txtpb",
+			},
+			code: exitOK,
+			stdout: `server:
+  http:
+    addr: 127.0.0.1:8080
+    timeout: 2s
+data:
+  database:
+    driver: mysql
+`,
+		},
+		{
+			name: "render sops",
+			args: []string{
+				"render", "-descriptor", descriptor, "-message", "conf.v1.Config",
+				"-sops-key-file", "../../parser/sops/testdata/key.txt", "../../parser/sops/testdata/config.enc.yaml",
+			},
+			code: exitOK,
+			stdout: `server:
+  http:
+    addr: 0.0.0.0:80
+    timeout: 1s
+data:
+  database:
+    driver: mysql
+    source: '[REDACTED]'
+  redis:
+    addr: 127.0.0.1:6379
+    read_timeout: 0.200s
+`,
+		},
+		{
+			name: "render expandenv not allowed",
+			args: []string{
+				"render", "-descriptor", descriptor, "-message", "conf.v1.Config",
+				"-expandenv-allow-prefix", "APP_", "../../conf/config-env-expand.yaml",
+			},
+			code:   exitFailure,
+			stderr: "expand server.grpc.addr: variable is not allowed: GRPC_ADDR",
+		},
+		{
+			name: "explain",
+			args: []string{
+				"explain", "-descriptor", descriptor, "-message", "conf.v1.Config",
+				"data.redis.read_timeout", "../../conf/config.yaml", "../../conf/config-overlay.json",
+			},
+			code:   exitOK,
+			stdout: "../../conf/config-overlay.json (file)\n",
+		},
+		{
+			name: "explain message",
+			args: []string{
+				"explain", "-descriptor", descriptor, "-message", "conf.v1.Config",
+				"data.redis", "../../conf/config.yaml", "../../conf/config-overlay.json",
+			},
+			code: exitOK,
+			stdout: "data.redis.addr: ../../conf/config.yaml:13:11 (file)\n" +
+				"data.redis.read_timeout: ../../conf/config-overlay.json (file)\n" +
+				"data.redis.write_timeout: ../../conf/config.yaml:15:20 (file)\n",
+		},
+		{
+			name: "explain invalid",
+			args: []string{
+				"explain", "-descriptor", descriptor, "-message", "conf.v1.ConfigWithValidate",
+				"server.http.addr", "../../conf/invalid-config.yaml",
+			},
+			code:   exitOK,
+			stdout: "../../conf/invalid-config.yaml:3:11 (file)\n",
+		},
+		{
+			name: "diff",
+			args: []string{
+				"diff", "-descriptor", descriptor, "-message", "conf.v1.Config", "-expandenv",
+				"../../conf/config.yaml", "../../conf/config.yaml",
+			},
+			code: exitOK,
+		},
+		{
+			name: "diff redacted",
+			args: []string{
+				"diff", "-descriptor", descriptor, "-message", "conf.v1.Config.Data.Database",
+				"../../conf/config-database.yaml", "../../conf/config-overlay.json",
+			},
+			code:   exitFailure,
+			stdout: "- driver: \"mysql\"\n- source: \"[REDACTED]\"\n",
+		},
+		{
+			name: "schema",
+			args: []string{
+				"schema", "-descriptor", descriptor, "-message", "conf.v1.Config.Server.Http", "-strict",
+			},
+			code: exitOK,
+			stdout: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "conf.v1.Config.Server.Http",
+  "type": "object",
+  "properties": {
+    "addr": {
+      "description": "HTTP listen address.",
+      "type": "string"
+    },
+    "timeout": {
+      "description": "HTTP request timeout.",
+      "type": "string",
+      "default": "1s",
+      "pattern": "^-?[0-9]+(\\.[0-9]{1,9})?s$"
+    }
+  },
+  "additionalProperties": false
+}
+`,
+		},
+		{
+			name: "docs",
+			args: []string{
+				"docs", "-descriptor", descriptor, "-message", "conf.v1.Config.Data.Database", "-env-prefix", "APP",
+			},
+			code: exitOK,
+			stdout: "# conf.v1.Config.Data.Database\n\n" +
+				"| Key | Type | Default | Description | Constraints | Env |\n" +
+				"|-----|------|---------|-------------|-------------|-----|\n" +
+				"| `driver` | `string` |  | Database driver name. |  | `APP_DRIVER` |\n" +
+				"| `source` | `string` |  | Database data source name. |  | `DATABASE_URL` |\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			code := run(test.args, &stdout, &stderr)
+
+			assert.Equal(t, test.code, code, stderr.String())
+			assert.Contains(t, stderr.String(), test.stderr)
+
+			if test.stdout != "" || test.code == exitOK {
+				assert.Equal(t, test.stdout, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRun_Secrets(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "db")
+	config := filepath.Join(dir, "config.yaml")
+
+	require.NoError(t, os.WriteFile(secret, []byte("root:secret@tcp(db:3306)/app"), 0o600))
+	require.NoError(t, os.WriteFile(config, []byte("driver: mysql\nsource: file://"+secret+"\n"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{
+		"render", "-descriptor", descriptor, "-message", "conf.v1.Config.Data.Database", "-output", "json",
+		"-secrets", "-show-sensitive", config,
+	}, &stdout, &stderr)
+
+	require.Equal(t, exitOK, code, stderr.String())
+	assert.Equal(t, `{
+  "driver": "mysql",
+  "source": "root:secret@tcp(db:3306)/app"
+}
+`, stdout.String())
+}
+
+func TestDiffLeaves(t *testing.T) {
+	t.Parallel()
+
+	changes := diffLeaves(
+		map[string]string{"a": `"1"`, "b": `"2"`, "c": `"3"`},
+		map[string]string{"a": `"1"`, "b": `"4"`, "d": `"5"`},
+	)
+
+	assert.Equal(t, []string{`- b: "2"`, `+ b: "4"`, `- c: "3"`, `+ d: "5"`}, changes)
+}
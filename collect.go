@@ -0,0 +1,351 @@
+package protoconf
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrorKind is the kind of a ConfigError.
+type ErrorKind int
+
+const (
+	// KindUnspecified is an error of an unknown kind.
+	KindUnspecified ErrorKind = iota
+	// KindSource is an error of reading or parsing a provider.
+	KindSource
+	// KindTransform is an error of a transformer.
+	KindTransform
+	// KindUnknownField is a key which is not a field of the message.
+	KindUnknownField
+	// KindType is a value which cannot be unmarshalled into its field.
+	KindType
+	// KindDefault is an invalid field default.
+	KindDefault
+	// KindViolation is a protovalidate violation.
+	KindViolation
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case KindSource:
+		return "source"
+	case KindTransform:
+		return "transform"
+	case KindUnknownField:
+		return "unknown field"
+	case KindType:
+		return "type"
+	case KindDefault:
+		return "default"
+	case KindViolation:
+		return "violation"
+	case KindUnspecified:
+	}
+
+	return "unspecified"
+}
+
+// MultiError is the list of the errors collected by a loader created
+// with WithCollectErrors.
+type MultiError struct {
+	Errors []*ConfigError
+}
+
+// Error renders the errors one per line.
+func (e *MultiError) Error() string {
+	lines := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		lines = append(lines, err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (e *MultiError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// collectSourceError records the error of a provider or a transformer.
+// It returns the error if it cannot be collected.
+func (c *ConfigLoader) collectSourceError(config *loaded, kind ErrorKind, source interface{}, err error) error {
+	if !c.opts.collectErrors || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	configErr := &ConfigError{
+		Kind:    kind,
+		Message: name(source) + ": " + err.Error(),
+		Err:     err,
+	}
+
+	fileProvider, ok := source.(FileProvider)
+	if ok {
+		configErr.File = fileProvider.Path()
+	}
+
+	config.errs = append(config.errs, configErr)
+
+	return nil
+}
+
+// collectTransformError records the errors of a transformer, one for every
+// joined error. The errors located by a PathError are reported at their
+// value. It returns the error if it cannot be collected.
+func (c *ConfigLoader) collectTransformError(config *loaded, t Transformer, err error) error {
+	if !c.opts.collectErrors || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	errs := []error{err}
+
+	joinedErr, ok := err.(interface{ Unwrap() []error }) //nolint:errorlint
+	if ok {
+		errs = joinedErr.Unwrap()
+	}
+
+	for _, err := range errs {
+		var pathErr PathError
+		if !errors.As(err, &pathErr) {
+			config.errs = append(config.errs, &ConfigError{
+				Kind:    KindTransform,
+				Message: name(t) + ": " + err.Error(),
+				Err:     err,
+			})
+
+			continue
+		}
+
+		configErr := newConfigError(pathErr.ValuePath(), config.origins)
+		configErr.Kind = KindTransform
+		configErr.Message = name(t) + ": " + pathErr.Unwrap().Error()
+		configErr.Err = err
+
+		config.errs = append(config.errs, configErr)
+	}
+
+	return nil
+}
+
+// scanAll is scan collecting all the errors. The values which cannot be
+// unmarshalled are skipped, so the other fields are still validated. The
+// values a transformer failed to rewrite are neither unmarshalled nor
+// validated.
+func (c *ConfigLoader) scanAll(config *loaded, message proto.Message) error {
+	desc := message.ProtoReflect().Descriptor()
+	errs := append([]*ConfigError(nil), config.errs...)
+	values := config.values
+
+	skipped := transformErrorPaths(config.errs)
+	if len(skipped) > 0 {
+		values = copyMap(values)
+
+		for _, path := range skipped {
+			deletePath(values, path)
+		}
+	}
+
+	if c.opts.strict {
+		for _, err := range unknownFields(desc, values, "", config.origins) {
+			errs = append(errs, unknownFieldConfigError(err))
+		}
+	}
+
+	typeErrs := typeErrors(desc, values, "", config.origins)
+	if len(typeErrs) > 0 {
+		if len(skipped) == 0 {
+			values = copyMap(values)
+		}
+
+		for _, err := range typeErrs {
+			configErr := asConfigError(err)
+			errs = append(errs, configErr)
+			deletePath(values, configErr.Path)
+		}
+	}
+
+	data, err := json.Marshal(values)
+	if err == nil {
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, message)
+	}
+
+	if err != nil {
+		return &MultiError{Errors: append(errs, &ConfigError{Kind: KindType, Message: err.Error(), Err: err})}
+	}
+
+	err = applyDefaults(message.ProtoReflect())
+	if err != nil {
+		errs = append(errs, &ConfigError{Kind: KindDefault, Message: err.Error(), Err: err})
+	}
+
+	err = c.validator.Validate(message)
+	if err != nil {
+		for _, violationErr := range violationErrors(err, config.origins) {
+			configErr := asConfigError(violationErr)
+			if !isSkipped(configErr.Path, skipped) {
+				errs = append(errs, configErr)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
+	}
+
+	return nil
+}
+
+// transformErrorPaths returns the paths of the values the transformers
+// failed to rewrite.
+func transformErrorPaths(errs []*ConfigError) []string {
+	var paths []string
+
+	for _, err := range errs {
+		if err.Kind == KindTransform && err.Path != "" {
+			paths = append(paths, err.Path)
+		}
+	}
+
+	return paths
+}
+
+// isSkipped reports whether the path is one of the skipped paths or
+// a value within one of them.
+func isSkipped(path string, skipped []string) bool {
+	for _, skippedPath := range skipped {
+		if path == skippedPath ||
+			strings.HasPrefix(path, skippedPath+".") ||
+			strings.HasPrefix(path, skippedPath+"[") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func unknownFieldConfigError(err error) *ConfigError {
+	var unknownErr *UnknownFieldError
+	if !errors.As(err, &unknownErr) {
+		return asConfigError(err)
+	}
+
+	configErr := &ConfigError{
+		Kind:    KindUnknownField,
+		Path:    unknownErr.Path,
+		Message: "unknown field",
+		Err:     unknownErr,
+	}
+
+	if unknownErr.Suggestion != "" {
+		configErr.Message += fmt.Sprintf(", did you mean %q?", unknownErr.Suggestion)
+	}
+
+	if unknownErr.Origin != nil {
+		configErr.File = unknownErr.Origin.File
+		configErr.Line = unknownErr.Origin.Position.Line
+		configErr.Column = unknownErr.Origin.Position.Column
+	}
+
+	return configErr
+}
+
+func asConfigError(err error) *ConfigError {
+	var configErr *ConfigError
+	if errors.As(err, &configErr) {
+		return configErr
+	}
+
+	return &ConfigError{Message: err.Error(), Err: err}
+}
+
+func copyMap(values map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		copied[key] = copyValue(value)
+	}
+
+	return copied
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copyMap(v)
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, elem := range v {
+			copied[i] = copyValue(elem)
+		}
+
+		return copied
+	default:
+		return value
+	}
+}
+
+// deletePath deletes the value at the path, e.g. `listeners[1].name`.
+func deletePath(values map[string]interface{}, path string) {
+	var current interface{} = values
+
+	segments := pathSegments(path)
+	for i, segment := range segments {
+		last := i == len(segments)-1
+
+		switch node := current.(type) {
+		case map[string]interface{}:
+			if last {
+				delete(node, segment)
+
+				return
+			}
+
+			current = node[segment]
+		case []interface{}:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return
+			}
+
+			if last {
+				node[idx] = nil
+
+				return
+			}
+
+			current = node[idx]
+		default:
+			return
+		}
+	}
+}
+
+// pathSegments splits the path into keys and list indexes.
+func pathSegments(path string) []string {
+	var segments []string
+
+	for _, part := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		segments = append(segments, key)
+
+		for rest != "" {
+			var idx string
+
+			idx, rest, _ = strings.Cut(rest, "]")
+			segments = append(segments, idx)
+			rest = strings.TrimPrefix(rest, "[")
+		}
+	}
+
+	return segments
+}
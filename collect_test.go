@@ -0,0 +1,199 @@
+package protoconf
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+	"github.com/gosynergy/protoconf/provider/file"
+	"github.com/gosynergy/protoconf/transform/expandenv"
+	"github.com/gosynergy/protoconf/transform/secrets"
+)
+
+func TestConfigLoader_CollectErrors(t *testing.T) {
+	t.Parallel()
+
+	errTransform := errors.New("transform failed")
+
+	transformer := NewMockTransformer(t)
+	transformer.EXPECT().Transform(mock.Anything).Return(nil, errTransform)
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/multi-error-config.yaml")),
+		WithParser(linePositionParser{yaml.Parser()}),
+		WithTransformers(transformer),
+		WithStrict(),
+		WithCollectErrors(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.ConfigWithValidate
+	err = loader.Scan(&cfg)
+	require.Error(t, err)
+
+	var multiErr *MultiError
+
+	require.True(t, errors.As(err, &multiErr))
+	require.Len(t, multiErr.Errors, 4)
+
+	kinds := make([]ErrorKind, 0, len(multiErr.Errors))
+	for _, configErr := range multiErr.Errors {
+		kinds = append(kinds, configErr.Kind)
+	}
+
+	assert.Equal(t, []ErrorKind{KindTransform, KindUnknownField, KindType, KindViolation}, kinds)
+	assert.Equal(t, "protoconf.MockTransformer: transform failed", multiErr.Errors[0].Error())
+	assert.Equal(t,
+		`conf/multi-error-config.yaml:6:5: server.grpc.timout: unknown field, did you mean "timeout"?`,
+		multiErr.Errors[1].Error())
+	assert.Equal(t,
+		`conf/multi-error-config.yaml:3:5: server.http.timeout: invalid google.protobuf.Duration value "invalid"`,
+		multiErr.Errors[2].Error())
+	assert.Equal(t, "server.http.addr: value is required [required]", multiErr.Errors[3].Error())
+
+	assert.ErrorIs(t, err, errTransform)
+	assert.ErrorIs(t, err, ErrUnknownField)
+	assert.Equal(t, "0.0.0.0:9000", cfg.GetServer().GetGrpc().GetAddr())
+}
+
+func TestConfigLoader_CollectErrorsSource(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/missing.yaml")),
+		WithProvider(file.Provider("conf/config.yaml")),
+		WithParser(yaml.Parser()),
+		WithCollectErrors(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.Config
+	err = loader.Scan(&cfg)
+
+	var multiErr *MultiError
+
+	require.True(t, errors.As(err, &multiErr))
+	require.Len(t, multiErr.Errors, 1)
+	assert.Equal(t, KindSource, multiErr.Errors[0].Kind)
+	assert.Equal(t, "conf/missing.yaml", multiErr.Errors[0].File)
+	assert.Equal(t, "mysql", cfg.GetData().GetDatabase().GetDriver())
+}
+
+func TestDeletePath(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]interface{}{
+		"listeners": []interface{}{
+			map[string]interface{}{"name": "a", "port": "x"},
+		},
+		"tags": "a",
+	}
+
+	deletePath(values, "listeners[0].port")
+	deletePath(values, "tags")
+	deletePath(values, "missing.key")
+
+	assert.Equal(t, map[string]interface{}{
+		"listeners": []interface{}{
+			map[string]interface{}{"name": "a"},
+		},
+	}, values)
+}
+
+func TestConfigLoader_CollectErrorsTransformValues(t *testing.T) {
+	t.Parallel()
+
+	envs := map[string]string{"GRPC_TIMEOUT": "2s"}
+
+	loader, err := New(
+		WithProvider(&memoryProvider{data: []byte(`server:
+  http:
+    addr: ${A}
+    timeout: ${T}
+  grpc:
+    addr: ${B}
+    timeout: ${GRPC_TIMEOUT}
+data:
+  database:
+    driver: mysql
+`)}),
+		WithParser(yaml.Parser()),
+		WithTransformers(expandenv.NewTransformer(
+			expandenv.WithStrict(),
+			expandenv.WithLookupEnv(func(s string) (string, bool) {
+				value, ok := envs[s]
+
+				return value, ok
+			}),
+		)),
+		WithCollectErrors(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.ConfigWithValidate
+	err = loader.Scan(&cfg)
+
+	var multiErr *MultiError
+
+	require.True(t, errors.As(err, &multiErr))
+
+	messages := make([]string, 0, len(multiErr.Errors))
+	for _, configErr := range multiErr.Errors {
+		assert.Equal(t, KindTransform, configErr.Kind)
+
+		messages = append(messages, configErr.Error())
+	}
+
+	assert.Equal(t, []string{
+		"server.grpc.addr: expandenv: variable is not set: B",
+		"server.http.addr: expandenv: variable is not set: A",
+		"server.http.timeout: expandenv: variable is not set: T",
+	}, messages)
+	assert.ErrorIs(t, err, expandenv.ErrUnset)
+	assert.Equal(t, 2*time.Second, cfg.GetServer().GetGrpc().GetTimeout().AsDuration())
+}
+
+func TestConfigLoader_CollectErrorsSecrets(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/config.yaml")),
+		WithProvider(&memoryProvider{data: []byte(`server:
+  http:
+    addr: secret://vault/http
+  grpc:
+    addr: secret://vault/missing
+`)}),
+		WithParser(linePositionParser{yaml.Parser()}),
+		WithTransformers(secrets.NewTransformer(
+			secrets.WithBackend("vault", secrets.NewMemoryResolver(map[string]string{"http": "0.0.0.0:80"})),
+		)),
+		WithCollectErrors(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.ConfigWithValidate
+	err = loader.Scan(&cfg)
+
+	var multiErr *MultiError
+
+	require.True(t, errors.As(err, &multiErr))
+	require.Len(t, multiErr.Errors, 1)
+	assert.Equal(t, KindTransform, multiErr.Errors[0].Kind)
+	assert.Equal(t, "server.grpc.addr", multiErr.Errors[0].Path)
+	assert.Equal(t, 5, multiErr.Errors[0].Line)
+	assert.Equal(t, "server.grpc.addr: secrets: resolve secret://vault/missing: secret not found",
+		multiErr.Errors[0].Error())
+	assert.ErrorIs(t, err, secrets.ErrNotFound)
+	assert.Equal(t, "0.0.0.0:80", cfg.GetServer().GetHttp().GetAddr())
+}
@@ -0,0 +1,391 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: conf/v1/config_with_constraints.proto
+
+package v1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Mode is the run mode.
+type ConfigWithConstraints_Mode int32
+
+const (
+	ConfigWithConstraints_MODE_UNSPECIFIED ConfigWithConstraints_Mode = 0
+	ConfigWithConstraints_MODE_DEV         ConfigWithConstraints_Mode = 1
+	ConfigWithConstraints_MODE_PROD        ConfigWithConstraints_Mode = 2
+)
+
+// Enum value maps for ConfigWithConstraints_Mode.
+var (
+	ConfigWithConstraints_Mode_name = map[int32]string{
+		0: "MODE_UNSPECIFIED",
+		1: "MODE_DEV",
+		2: "MODE_PROD",
+	}
+	ConfigWithConstraints_Mode_value = map[string]int32{
+		"MODE_UNSPECIFIED": 0,
+		"MODE_DEV":         1,
+		"MODE_PROD":        2,
+	}
+)
+
+func (x ConfigWithConstraints_Mode) Enum() *ConfigWithConstraints_Mode {
+	p := new(ConfigWithConstraints_Mode)
+	*p = x
+	return p
+}
+
+func (x ConfigWithConstraints_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfigWithConstraints_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_conf_v1_config_with_constraints_proto_enumTypes[0].Descriptor()
+}
+
+func (ConfigWithConstraints_Mode) Type() protoreflect.EnumType {
+	return &file_conf_v1_config_with_constraints_proto_enumTypes[0]
+}
+
+func (x ConfigWithConstraints_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigWithConstraints_Mode.Descriptor instead.
+func (ConfigWithConstraints_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_conf_v1_config_with_constraints_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// ConfigWithConstraints is a configuration with common validation constraints.
+type ConfigWithConstraints struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the service.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Port to listen on.
+	Port       uint32                     `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Mode       ConfigWithConstraints_Mode `protobuf:"varint,3,opt,name=mode,proto3,enum=conf.v1.ConfigWithConstraints_Mode" json:"mode,omitempty"`
+	Region     string                     `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	AdminEmail string                     `protobuf:"bytes,5,opt,name=admin_email,json=adminEmail,proto3" json:"admin_email,omitempty"`
+	Hosts      []string                   `protobuf:"bytes,6,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	Limits     map[string]int64           `protobuf:"bytes,7,rep,name=limits,proto3" json:"limits,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Timeout    *durationpb.Duration       `protobuf:"bytes,8,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	NotBefore  *timestamppb.Timestamp     `protobuf:"bytes,9,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	Retries    *wrapperspb.UInt32Value    `protobuf:"bytes,10,opt,name=retries,proto3" json:"retries,omitempty"`
+	// Deprecated: Marked as deprecated in conf/v1/config_with_constraints.proto.
+	Legacy string `protobuf:"bytes,11,opt,name=legacy,proto3" json:"legacy,omitempty"`
+	// Types that are assignable to Store:
+	//	*ConfigWithConstraints_File
+	//	*ConfigWithConstraints_Url
+	Store isConfigWithConstraints_Store `protobuf_oneof:"store"`
+}
+
+func (x *ConfigWithConstraints) Reset() {
+	*x = ConfigWithConstraints{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_conf_v1_config_with_constraints_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigWithConstraints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigWithConstraints) ProtoMessage() {}
+
+func (x *ConfigWithConstraints) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_v1_config_with_constraints_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigWithConstraints.ProtoReflect.Descriptor instead.
+func (*ConfigWithConstraints) Descriptor() ([]byte, []int) {
+	return file_conf_v1_config_with_constraints_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConfigWithConstraints) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConfigWithConstraints) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ConfigWithConstraints) GetMode() ConfigWithConstraints_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return ConfigWithConstraints_MODE_UNSPECIFIED
+}
+
+func (x *ConfigWithConstraints) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ConfigWithConstraints) GetAdminEmail() string {
+	if x != nil {
+		return x.AdminEmail
+	}
+	return ""
+}
+
+func (x *ConfigWithConstraints) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *ConfigWithConstraints) GetLimits() map[string]int64 {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *ConfigWithConstraints) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *ConfigWithConstraints) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *ConfigWithConstraints) GetRetries() *wrapperspb.UInt32Value {
+	if x != nil {
+		return x.Retries
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in conf/v1/config_with_constraints.proto.
+func (x *ConfigWithConstraints) GetLegacy() string {
+	if x != nil {
+		return x.Legacy
+	}
+	return ""
+}
+
+func (m *ConfigWithConstraints) GetStore() isConfigWithConstraints_Store {
+	if m != nil {
+		return m.Store
+	}
+	return nil
+}
+
+func (x *ConfigWithConstraints) GetFile() string {
+	if x, ok := x.GetStore().(*ConfigWithConstraints_File); ok {
+		return x.File
+	}
+	return ""
+}
+
+func (x *ConfigWithConstraints) GetUrl() string {
+	if x, ok := x.GetStore().(*ConfigWithConstraints_Url); ok {
+		return x.Url
+	}
+	return ""
+}
+
+type isConfigWithConstraints_Store interface {
+	isConfigWithConstraints_Store()
+}
+
+type ConfigWithConstraints_File struct {
+	File string `protobuf:"bytes,12,opt,name=file,proto3,oneof"`
+}
+
+type ConfigWithConstraints_Url struct {
+	Url string `protobuf:"bytes,13,opt,name=url,proto3,oneof"`
+}
+
+func (*ConfigWithConstraints_File) isConfigWithConstraints_Store() {}
+
+func (*ConfigWithConstraints_Url) isConfigWithConstraints_Store() {}
+
+var File_conf_v1_config_with_constraints_proto protoreflect.FileDescriptor
+
+var file_conf_v1_config_with_constraints_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x63, 0x6f, 0x6e, 0x66, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31,
+	0x1a, 0x1b, 0x62, 0x75, 0x66, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe7,
+	0x05, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57, 0x69, 0x74, 0x68, 0x43, 0x6f, 0x6e,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x17, 0xba, 0x48, 0x14, 0xc8, 0x01, 0x01, 0x72, 0x0f,
+	0x10, 0x03, 0x18, 0x20, 0x32, 0x09, 0x5e, 0x5b, 0x61, 0x2d, 0x7a, 0x2d, 0x5d, 0x2b, 0x24, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x42, 0x0b, 0xba, 0x48, 0x08, 0x2a, 0x06, 0x18, 0xff, 0xff, 0x03, 0x28, 0x01,
+	0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x43, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57, 0x69, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x6e, 0x74, 0x73, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x42, 0x0a, 0xba, 0x48, 0x07, 0x82, 0x01,
+	0x04, 0x1a, 0x02, 0x01, 0x02, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x72,
+	0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0d, 0xba, 0x48, 0x0a,
+	0x72, 0x08, 0x52, 0x02, 0x65, 0x75, 0x52, 0x02, 0x75, 0x73, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69,
+	0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0b, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x65, 0x6d, 0x61, 0x69,
+	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xba, 0x48, 0x04, 0x72, 0x02, 0x60, 0x01,
+	0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x26, 0x0a, 0x05,
+	0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x42, 0x10, 0xba, 0x48, 0x0d,
+	0x92, 0x01, 0x0a, 0x08, 0x01, 0x18, 0x01, 0x22, 0x04, 0x72, 0x02, 0x68, 0x01, 0x52, 0x05, 0x68,
+	0x6f, 0x73, 0x74, 0x73, 0x12, 0x4c, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57, 0x69, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x6e, 0x74, 0x73, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x42, 0x08, 0xba, 0x48, 0x05, 0x9a, 0x01, 0x02, 0x10, 0x08, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x5f, 0x62,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x42, 0x65, 0x66, 0x6f,
+	0x72, 0x65, 0x12, 0x3f, 0x0a, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x42, 0x07, 0xba, 0x48, 0x04, 0x2a, 0x02, 0x18, 0x0a, 0x52, 0x07, 0x72, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x06, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x12,
+	0x14, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x39, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x10,
+	0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x44, 0x45, 0x56, 0x10, 0x01,
+	0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x44, 0x10, 0x02, 0x42,
+	0x07, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x42, 0x09, 0x5a, 0x07, 0x63, 0x6f, 0x6e, 0x66,
+	0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_conf_v1_config_with_constraints_proto_rawDescOnce sync.Once
+	file_conf_v1_config_with_constraints_proto_rawDescData = file_conf_v1_config_with_constraints_proto_rawDesc
+)
+
+func file_conf_v1_config_with_constraints_proto_rawDescGZIP() []byte {
+	file_conf_v1_config_with_constraints_proto_rawDescOnce.Do(func() {
+		file_conf_v1_config_with_constraints_proto_rawDescData = protoimpl.X.CompressGZIP(file_conf_v1_config_with_constraints_proto_rawDescData)
+	})
+	return file_conf_v1_config_with_constraints_proto_rawDescData
+}
+
+var file_conf_v1_config_with_constraints_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_conf_v1_config_with_constraints_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_conf_v1_config_with_constraints_proto_goTypes = []interface{}{
+	(ConfigWithConstraints_Mode)(0), // 0: conf.v1.ConfigWithConstraints.Mode
+	(*ConfigWithConstraints)(nil),   // 1: conf.v1.ConfigWithConstraints
+	nil,                             // 2: conf.v1.ConfigWithConstraints.LimitsEntry
+	(*durationpb.Duration)(nil),     // 3: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),   // 4: google.protobuf.Timestamp
+	(*wrapperspb.UInt32Value)(nil),  // 5: google.protobuf.UInt32Value
+}
+var file_conf_v1_config_with_constraints_proto_depIdxs = []int32{
+	0, // 0: conf.v1.ConfigWithConstraints.mode:type_name -> conf.v1.ConfigWithConstraints.Mode
+	2, // 1: conf.v1.ConfigWithConstraints.limits:type_name -> conf.v1.ConfigWithConstraints.LimitsEntry
+	3, // 2: conf.v1.ConfigWithConstraints.timeout:type_name -> google.protobuf.Duration
+	4, // 3: conf.v1.ConfigWithConstraints.not_before:type_name -> google.protobuf.Timestamp
+	5, // 4: conf.v1.ConfigWithConstraints.retries:type_name -> google.protobuf.UInt32Value
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_conf_v1_config_with_constraints_proto_init() }
+func file_conf_v1_config_with_constraints_proto_init() {
+	if File_conf_v1_config_with_constraints_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_conf_v1_config_with_constraints_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigWithConstraints); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_conf_v1_config_with_constraints_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*ConfigWithConstraints_File)(nil),
+		(*ConfigWithConstraints_Url)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_conf_v1_config_with_constraints_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_conf_v1_config_with_constraints_proto_goTypes,
+		DependencyIndexes: file_conf_v1_config_with_constraints_proto_depIdxs,
+		EnumInfos:         file_conf_v1_config_with_constraints_proto_enumTypes,
+		MessageInfos:      file_conf_v1_config_with_constraints_proto_msgTypes,
+	}.Build()
+	File_conf_v1_config_with_constraints_proto = out.File
+	file_conf_v1_config_with_constraints_proto_rawDesc = nil
+	file_conf_v1_config_with_constraints_proto_goTypes = nil
+	file_conf_v1_config_with_constraints_proto_depIdxs = nil
+}
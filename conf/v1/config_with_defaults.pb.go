@@ -0,0 +1,408 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: conf/v1/config_with_defaults.proto
+
+package v1
+
+import (
+	_ "github.com/gosynergy/protoconf/protoconf"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConfigWithDefaults_Level int32
+
+const (
+	ConfigWithDefaults_LEVEL_UNSPECIFIED ConfigWithDefaults_Level = 0
+	ConfigWithDefaults_LEVEL_DEBUG       ConfigWithDefaults_Level = 1
+	ConfigWithDefaults_LEVEL_INFO        ConfigWithDefaults_Level = 2
+)
+
+// Enum value maps for ConfigWithDefaults_Level.
+var (
+	ConfigWithDefaults_Level_name = map[int32]string{
+		0: "LEVEL_UNSPECIFIED",
+		1: "LEVEL_DEBUG",
+		2: "LEVEL_INFO",
+	}
+	ConfigWithDefaults_Level_value = map[string]int32{
+		"LEVEL_UNSPECIFIED": 0,
+		"LEVEL_DEBUG":       1,
+		"LEVEL_INFO":        2,
+	}
+)
+
+func (x ConfigWithDefaults_Level) Enum() *ConfigWithDefaults_Level {
+	p := new(ConfigWithDefaults_Level)
+	*p = x
+	return p
+}
+
+func (x ConfigWithDefaults_Level) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfigWithDefaults_Level) Descriptor() protoreflect.EnumDescriptor {
+	return file_conf_v1_config_with_defaults_proto_enumTypes[0].Descriptor()
+}
+
+func (ConfigWithDefaults_Level) Type() protoreflect.EnumType {
+	return &file_conf_v1_config_with_defaults_proto_enumTypes[0]
+}
+
+func (x ConfigWithDefaults_Level) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigWithDefaults_Level.Descriptor instead.
+func (ConfigWithDefaults_Level) EnumDescriptor() ([]byte, []int) {
+	return file_conf_v1_config_with_defaults_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type ConfigWithDefaults struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server *ConfigWithDefaults_Server `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Log    *ConfigWithDefaults_Log    `protobuf:"bytes,2,opt,name=log,proto3" json:"log,omitempty"`
+	Ratio  float64                    `protobuf:"fixed64,3,opt,name=ratio,proto3" json:"ratio,omitempty"`
+}
+
+func (x *ConfigWithDefaults) Reset() {
+	*x = ConfigWithDefaults{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_conf_v1_config_with_defaults_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigWithDefaults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigWithDefaults) ProtoMessage() {}
+
+func (x *ConfigWithDefaults) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_v1_config_with_defaults_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigWithDefaults.ProtoReflect.Descriptor instead.
+func (*ConfigWithDefaults) Descriptor() ([]byte, []int) {
+	return file_conf_v1_config_with_defaults_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConfigWithDefaults) GetServer() *ConfigWithDefaults_Server {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+func (x *ConfigWithDefaults) GetLog() *ConfigWithDefaults_Log {
+	if x != nil {
+		return x.Log
+	}
+	return nil
+}
+
+func (x *ConfigWithDefaults) GetRatio() float64 {
+	if x != nil {
+		return x.Ratio
+	}
+	return 0
+}
+
+type ConfigWithDefaults_Server struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addr     string               `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Timeout  *durationpb.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	MaxConns uint32               `protobuf:"varint,3,opt,name=max_conns,json=maxConns,proto3" json:"max_conns,omitempty"`
+	Hosts    []string             `protobuf:"bytes,4,rep,name=hosts,proto3" json:"hosts,omitempty"`
+}
+
+func (x *ConfigWithDefaults_Server) Reset() {
+	*x = ConfigWithDefaults_Server{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_conf_v1_config_with_defaults_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigWithDefaults_Server) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigWithDefaults_Server) ProtoMessage() {}
+
+func (x *ConfigWithDefaults_Server) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_v1_config_with_defaults_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigWithDefaults_Server.ProtoReflect.Descriptor instead.
+func (*ConfigWithDefaults_Server) Descriptor() ([]byte, []int) {
+	return file_conf_v1_config_with_defaults_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ConfigWithDefaults_Server) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *ConfigWithDefaults_Server) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *ConfigWithDefaults_Server) GetMaxConns() uint32 {
+	if x != nil {
+		return x.MaxConns
+	}
+	return 0
+}
+
+func (x *ConfigWithDefaults_Server) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+type ConfigWithDefaults_Log struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level ConfigWithDefaults_Level `protobuf:"varint,1,opt,name=level,proto3,enum=conf.v1.ConfigWithDefaults_Level" json:"level,omitempty"`
+	Json  *bool                    `protobuf:"varint,2,opt,name=json,proto3,oneof" json:"json,omitempty"`
+}
+
+func (x *ConfigWithDefaults_Log) Reset() {
+	*x = ConfigWithDefaults_Log{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_conf_v1_config_with_defaults_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigWithDefaults_Log) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigWithDefaults_Log) ProtoMessage() {}
+
+func (x *ConfigWithDefaults_Log) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_v1_config_with_defaults_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigWithDefaults_Log.ProtoReflect.Descriptor instead.
+func (*ConfigWithDefaults_Log) Descriptor() ([]byte, []int) {
+	return file_conf_v1_config_with_defaults_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *ConfigWithDefaults_Log) GetLevel() ConfigWithDefaults_Level {
+	if x != nil {
+		return x.Level
+	}
+	return ConfigWithDefaults_LEVEL_UNSPECIFIED
+}
+
+func (x *ConfigWithDefaults_Log) GetJson() bool {
+	if x != nil && x.Json != nil {
+		return *x.Json
+	}
+	return false
+}
+
+var File_conf_v1_config_with_defaults_proto protoreflect.FileDescriptor
+
+var file_conf_v1_config_with_defaults_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x63, 0x6f, 0x6e, 0x66, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6e, 0x66, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbb, 0x04, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x57, 0x69, 0x74, 0x68, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x53, 0x0a,
+	0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57, 0x69,
+	0x74, 0x68, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x42, 0x17, 0x82, 0x80, 0x19, 0x13, 0x12, 0x11, 0x7b, 0x22, 0x61, 0x64, 0x64, 0x72, 0x22,
+	0x3a, 0x20, 0x22, 0x3a, 0x39, 0x30, 0x39, 0x30, 0x22, 0x7d, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x12, 0x31, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x57, 0x69, 0x74, 0x68, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x4c, 0x6f, 0x67,
+	0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1f, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x42, 0x09, 0x82, 0x80, 0x19, 0x05, 0x12, 0x03, 0x30, 0x2e, 0x35, 0x52,
+	0x05, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x1a, 0xba, 0x01, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x12, 0x1f, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x0b, 0x82, 0x80, 0x19, 0x07, 0x12, 0x05, 0x3a, 0x38, 0x30, 0x38, 0x30, 0x52, 0x04, 0x61, 0x64,
+	0x64, 0x72, 0x12, 0x3f, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0a,
+	0x82, 0x80, 0x19, 0x06, 0x12, 0x04, 0x31, 0x2e, 0x35, 0x73, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x12, 0x26, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x09, 0x82, 0x80, 0x19, 0x05, 0x12, 0x03, 0x31, 0x30,
+	0x30, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x68,
+	0x6f, 0x73, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x42, 0x10, 0x82, 0x80, 0x19, 0x0c,
+	0x12, 0x0a, 0x5b, 0x22, 0x61, 0x22, 0x2c, 0x20, 0x22, 0x62, 0x22, 0x5d, 0x52, 0x05, 0x68, 0x6f,
+	0x73, 0x74, 0x73, 0x1a, 0x7e, 0x0a, 0x03, 0x4c, 0x6f, 0x67, 0x12, 0x49, 0x0a, 0x05, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57, 0x69, 0x74, 0x68, 0x44, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x42, 0x10, 0x82, 0x80,
+	0x19, 0x0c, 0x12, 0x0a, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x52, 0x05,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x23, 0x0a, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x42, 0x0a, 0x82, 0x80, 0x19, 0x06, 0x12, 0x04, 0x74, 0x72, 0x75, 0x65, 0x48,
+	0x00, 0x52, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6a,
+	0x73, 0x6f, 0x6e, 0x22, 0x3f, 0x0a, 0x05, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x15, 0x0a, 0x11,
+	0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x44, 0x45, 0x42,
+	0x55, 0x47, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x49, 0x4e,
+	0x46, 0x4f, 0x10, 0x02, 0x42, 0x09, 0x5a, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x2f, 0x76, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_conf_v1_config_with_defaults_proto_rawDescOnce sync.Once
+	file_conf_v1_config_with_defaults_proto_rawDescData = file_conf_v1_config_with_defaults_proto_rawDesc
+)
+
+func file_conf_v1_config_with_defaults_proto_rawDescGZIP() []byte {
+	file_conf_v1_config_with_defaults_proto_rawDescOnce.Do(func() {
+		file_conf_v1_config_with_defaults_proto_rawDescData = protoimpl.X.CompressGZIP(file_conf_v1_config_with_defaults_proto_rawDescData)
+	})
+	return file_conf_v1_config_with_defaults_proto_rawDescData
+}
+
+var file_conf_v1_config_with_defaults_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_conf_v1_config_with_defaults_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_conf_v1_config_with_defaults_proto_goTypes = []interface{}{
+	(ConfigWithDefaults_Level)(0),     // 0: conf.v1.ConfigWithDefaults.Level
+	(*ConfigWithDefaults)(nil),        // 1: conf.v1.ConfigWithDefaults
+	(*ConfigWithDefaults_Server)(nil), // 2: conf.v1.ConfigWithDefaults.Server
+	(*ConfigWithDefaults_Log)(nil),    // 3: conf.v1.ConfigWithDefaults.Log
+	(*durationpb.Duration)(nil),       // 4: google.protobuf.Duration
+}
+var file_conf_v1_config_with_defaults_proto_depIdxs = []int32{
+	2, // 0: conf.v1.ConfigWithDefaults.server:type_name -> conf.v1.ConfigWithDefaults.Server
+	3, // 1: conf.v1.ConfigWithDefaults.log:type_name -> conf.v1.ConfigWithDefaults.Log
+	4, // 2: conf.v1.ConfigWithDefaults.Server.timeout:type_name -> google.protobuf.Duration
+	0, // 3: conf.v1.ConfigWithDefaults.Log.level:type_name -> conf.v1.ConfigWithDefaults.Level
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_conf_v1_config_with_defaults_proto_init() }
+func file_conf_v1_config_with_defaults_proto_init() {
+	if File_conf_v1_config_with_defaults_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_conf_v1_config_with_defaults_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigWithDefaults); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_conf_v1_config_with_defaults_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigWithDefaults_Server); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_conf_v1_config_with_defaults_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigWithDefaults_Log); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_conf_v1_config_with_defaults_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_conf_v1_config_with_defaults_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_conf_v1_config_with_defaults_proto_goTypes,
+		DependencyIndexes: file_conf_v1_config_with_defaults_proto_depIdxs,
+		EnumInfos:         file_conf_v1_config_with_defaults_proto_enumTypes,
+		MessageInfos:      file_conf_v1_config_with_defaults_proto_msgTypes,
+	}.Build()
+	File_conf_v1_config_with_defaults_proto = out.File
+	file_conf_v1_config_with_defaults_proto_rawDesc = nil
+	file_conf_v1_config_with_defaults_proto_goTypes = nil
+	file_conf_v1_config_with_defaults_proto_depIdxs = nil
+}
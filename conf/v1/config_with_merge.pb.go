@@ -0,0 +1,268 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: conf/v1/config_with_merge.proto
+
+package v1
+
+import (
+	_ "github.com/gosynergy/protoconf/protoconf"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConfigWithMerge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Listeners []*ConfigWithMerge_Listener `protobuf:"bytes,1,rep,name=listeners,proto3" json:"listeners,omitempty"`
+	Tags      []string                    `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Labels    map[string]string           `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ConfigWithMerge) Reset() {
+	*x = ConfigWithMerge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_conf_v1_config_with_merge_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigWithMerge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigWithMerge) ProtoMessage() {}
+
+func (x *ConfigWithMerge) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_v1_config_with_merge_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigWithMerge.ProtoReflect.Descriptor instead.
+func (*ConfigWithMerge) Descriptor() ([]byte, []int) {
+	return file_conf_v1_config_with_merge_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConfigWithMerge) GetListeners() []*ConfigWithMerge_Listener {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+func (x *ConfigWithMerge) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ConfigWithMerge) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type ConfigWithMerge_Listener struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Addr   string            `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Labels map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ConfigWithMerge_Listener) Reset() {
+	*x = ConfigWithMerge_Listener{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_conf_v1_config_with_merge_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigWithMerge_Listener) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigWithMerge_Listener) ProtoMessage() {}
+
+func (x *ConfigWithMerge_Listener) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_v1_config_with_merge_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigWithMerge_Listener.ProtoReflect.Descriptor instead.
+func (*ConfigWithMerge_Listener) Descriptor() ([]byte, []int) {
+	return file_conf_v1_config_with_merge_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ConfigWithMerge_Listener) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConfigWithMerge_Listener) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *ConfigWithMerge_Listener) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+var File_conf_v1_config_with_merge_proto protoreflect.FileDescriptor
+
+var file_conf_v1_config_with_merge_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x63, 0x6f, 0x6e, 0x66, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31, 0x1a, 0x17, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6e, 0x66, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0xba, 0x03, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57, 0x69,
+	0x74, 0x68, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x12, 0x4f, 0x0a, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57, 0x69, 0x74, 0x68, 0x4d,
+	0x65, 0x72, 0x67, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x42, 0x0e, 0x82,
+	0x80, 0x19, 0x0a, 0x0a, 0x08, 0x10, 0x03, 0x1a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x09, 0x6c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x42, 0x08, 0x82, 0x80, 0x19, 0x04, 0x0a, 0x02, 0x10, 0x02,
+	0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x46, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x72, 0x67, 0x65,
+	0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x08, 0x82, 0x80,
+	0x19, 0x04, 0x0a, 0x02, 0x08, 0x02, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0xb4,
+	0x01, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61,
+	0x64, 0x64, 0x72, 0x12, 0x45, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x42, 0x09, 0x5a, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_conf_v1_config_with_merge_proto_rawDescOnce sync.Once
+	file_conf_v1_config_with_merge_proto_rawDescData = file_conf_v1_config_with_merge_proto_rawDesc
+)
+
+func file_conf_v1_config_with_merge_proto_rawDescGZIP() []byte {
+	file_conf_v1_config_with_merge_proto_rawDescOnce.Do(func() {
+		file_conf_v1_config_with_merge_proto_rawDescData = protoimpl.X.CompressGZIP(file_conf_v1_config_with_merge_proto_rawDescData)
+	})
+	return file_conf_v1_config_with_merge_proto_rawDescData
+}
+
+var file_conf_v1_config_with_merge_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_conf_v1_config_with_merge_proto_goTypes = []interface{}{
+	(*ConfigWithMerge)(nil),          // 0: conf.v1.ConfigWithMerge
+	(*ConfigWithMerge_Listener)(nil), // 1: conf.v1.ConfigWithMerge.Listener
+	nil,                              // 2: conf.v1.ConfigWithMerge.LabelsEntry
+	nil,                              // 3: conf.v1.ConfigWithMerge.Listener.LabelsEntry
+}
+var file_conf_v1_config_with_merge_proto_depIdxs = []int32{
+	1, // 0: conf.v1.ConfigWithMerge.listeners:type_name -> conf.v1.ConfigWithMerge.Listener
+	2, // 1: conf.v1.ConfigWithMerge.labels:type_name -> conf.v1.ConfigWithMerge.LabelsEntry
+	3, // 2: conf.v1.ConfigWithMerge.Listener.labels:type_name -> conf.v1.ConfigWithMerge.Listener.LabelsEntry
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_conf_v1_config_with_merge_proto_init() }
+func file_conf_v1_config_with_merge_proto_init() {
+	if File_conf_v1_config_with_merge_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_conf_v1_config_with_merge_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigWithMerge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_conf_v1_config_with_merge_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigWithMerge_Listener); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_conf_v1_config_with_merge_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_conf_v1_config_with_merge_proto_goTypes,
+		DependencyIndexes: file_conf_v1_config_with_merge_proto_depIdxs,
+		MessageInfos:      file_conf_v1_config_with_merge_proto_msgTypes,
+	}.Build()
+	File_conf_v1_config_with_merge_proto = out.File
+	file_conf_v1_config_with_merge_proto_rawDesc = nil
+	file_conf_v1_config_with_merge_proto_goTypes = nil
+	file_conf_v1_config_with_merge_proto_depIdxs = nil
+}
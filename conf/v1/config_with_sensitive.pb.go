@@ -0,0 +1,321 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: conf/v1/config_with_sensitive.proto
+
+package v1
+
+import (
+	_ "github.com/gosynergy/protoconf/protoconf"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConfigWithSensitive struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Users      []*ConfigWithSensitive_Credentials          `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Backends   map[string]*ConfigWithSensitive_Credentials `protobuf:"bytes,2,rep,name=backends,proto3" json:"backends,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Extension  *anypb.Any                                  `protobuf:"bytes,3,opt,name=extension,proto3" json:"extension,omitempty"`
+	Tokens     []string                                    `protobuf:"bytes,4,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	Keys       map[string]string                           `protobuf:"bytes,5,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	PrivateKey []byte                                      `protobuf:"bytes,6,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	Pin        uint32                                      `protobuf:"varint,7,opt,name=pin,proto3" json:"pin,omitempty"`
+	Admin      *ConfigWithSensitive_Credentials            `protobuf:"bytes,8,opt,name=admin,proto3" json:"admin,omitempty"`
+}
+
+func (x *ConfigWithSensitive) Reset() {
+	*x = ConfigWithSensitive{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_conf_v1_config_with_sensitive_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigWithSensitive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigWithSensitive) ProtoMessage() {}
+
+func (x *ConfigWithSensitive) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_v1_config_with_sensitive_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigWithSensitive.ProtoReflect.Descriptor instead.
+func (*ConfigWithSensitive) Descriptor() ([]byte, []int) {
+	return file_conf_v1_config_with_sensitive_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConfigWithSensitive) GetUsers() []*ConfigWithSensitive_Credentials {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ConfigWithSensitive) GetBackends() map[string]*ConfigWithSensitive_Credentials {
+	if x != nil {
+		return x.Backends
+	}
+	return nil
+}
+
+func (x *ConfigWithSensitive) GetExtension() *anypb.Any {
+	if x != nil {
+		return x.Extension
+	}
+	return nil
+}
+
+func (x *ConfigWithSensitive) GetTokens() []string {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *ConfigWithSensitive) GetKeys() map[string]string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *ConfigWithSensitive) GetPrivateKey() []byte {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return nil
+}
+
+func (x *ConfigWithSensitive) GetPin() uint32 {
+	if x != nil {
+		return x.Pin
+	}
+	return 0
+}
+
+func (x *ConfigWithSensitive) GetAdmin() *ConfigWithSensitive_Credentials {
+	if x != nil {
+		return x.Admin
+	}
+	return nil
+}
+
+type ConfigWithSensitive_Credentials struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User     string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (x *ConfigWithSensitive_Credentials) Reset() {
+	*x = ConfigWithSensitive_Credentials{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_conf_v1_config_with_sensitive_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigWithSensitive_Credentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigWithSensitive_Credentials) ProtoMessage() {}
+
+func (x *ConfigWithSensitive_Credentials) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_v1_config_with_sensitive_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigWithSensitive_Credentials.ProtoReflect.Descriptor instead.
+func (*ConfigWithSensitive_Credentials) Descriptor() ([]byte, []int) {
+	return file_conf_v1_config_with_sensitive_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ConfigWithSensitive_Credentials) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *ConfigWithSensitive_Credentials) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+var File_conf_v1_config_with_sensitive_proto protoreflect.FileDescriptor
+
+var file_conf_v1_config_with_sensitive_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x63, 0x6f, 0x6e, 0x66, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31, 0x1a, 0x19,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6e, 0x66, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xa7, 0x05, 0x0a, 0x13, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57, 0x69, 0x74,
+	0x68, 0x53, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x3e, 0x0a, 0x05, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57, 0x69, 0x74, 0x68, 0x53, 0x65,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x61, 0x6c, 0x73, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x46, 0x0a, 0x08, 0x62, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57, 0x69, 0x74,
+	0x68, 0x53, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x73, 0x12, 0x32, 0x0a, 0x09, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x09, 0x65, 0x78, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x42, 0x06, 0x82, 0x80, 0x19, 0x02, 0x20, 0x01, 0x52, 0x06,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x42, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57, 0x69, 0x74, 0x68, 0x53, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x76, 0x65, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x06, 0x82, 0x80,
+	0x19, 0x02, 0x20, 0x01, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x27, 0x0a, 0x0b, 0x70, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x42,
+	0x06, 0x82, 0x80, 0x19, 0x02, 0x20, 0x01, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x03, 0x70, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d,
+	0x42, 0x06, 0x82, 0x80, 0x19, 0x02, 0x20, 0x01, 0x52, 0x03, 0x70, 0x69, 0x6e, 0x12, 0x46, 0x0a,
+	0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57, 0x69, 0x74,
+	0x68, 0x53, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x42, 0x06, 0x82, 0x80, 0x19, 0x02, 0x20, 0x01, 0x52, 0x05,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x1a, 0x45, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x06, 0x82, 0x80, 0x19, 0x02,
+	0x20, 0x01, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x1a, 0x65, 0x0a, 0x0d,
+	0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x3e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x57,
+	0x69, 0x74, 0x68, 0x53, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x43, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x1a, 0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x5a, 0x07,
+	0x63, 0x6f, 0x6e, 0x66, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_conf_v1_config_with_sensitive_proto_rawDescOnce sync.Once
+	file_conf_v1_config_with_sensitive_proto_rawDescData = file_conf_v1_config_with_sensitive_proto_rawDesc
+)
+
+func file_conf_v1_config_with_sensitive_proto_rawDescGZIP() []byte {
+	file_conf_v1_config_with_sensitive_proto_rawDescOnce.Do(func() {
+		file_conf_v1_config_with_sensitive_proto_rawDescData = protoimpl.X.CompressGZIP(file_conf_v1_config_with_sensitive_proto_rawDescData)
+	})
+	return file_conf_v1_config_with_sensitive_proto_rawDescData
+}
+
+var file_conf_v1_config_with_sensitive_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_conf_v1_config_with_sensitive_proto_goTypes = []interface{}{
+	(*ConfigWithSensitive)(nil),             // 0: conf.v1.ConfigWithSensitive
+	(*ConfigWithSensitive_Credentials)(nil), // 1: conf.v1.ConfigWithSensitive.Credentials
+	nil,                                     // 2: conf.v1.ConfigWithSensitive.BackendsEntry
+	nil,                                     // 3: conf.v1.ConfigWithSensitive.KeysEntry
+	(*anypb.Any)(nil),                       // 4: google.protobuf.Any
+}
+var file_conf_v1_config_with_sensitive_proto_depIdxs = []int32{
+	1, // 0: conf.v1.ConfigWithSensitive.users:type_name -> conf.v1.ConfigWithSensitive.Credentials
+	2, // 1: conf.v1.ConfigWithSensitive.backends:type_name -> conf.v1.ConfigWithSensitive.BackendsEntry
+	4, // 2: conf.v1.ConfigWithSensitive.extension:type_name -> google.protobuf.Any
+	3, // 3: conf.v1.ConfigWithSensitive.keys:type_name -> conf.v1.ConfigWithSensitive.KeysEntry
+	1, // 4: conf.v1.ConfigWithSensitive.admin:type_name -> conf.v1.ConfigWithSensitive.Credentials
+	1, // 5: conf.v1.ConfigWithSensitive.BackendsEntry.value:type_name -> conf.v1.ConfigWithSensitive.Credentials
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_conf_v1_config_with_sensitive_proto_init() }
+func file_conf_v1_config_with_sensitive_proto_init() {
+	if File_conf_v1_config_with_sensitive_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_conf_v1_config_with_sensitive_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigWithSensitive); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_conf_v1_config_with_sensitive_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigWithSensitive_Credentials); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_conf_v1_config_with_sensitive_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_conf_v1_config_with_sensitive_proto_goTypes,
+		DependencyIndexes: file_conf_v1_config_with_sensitive_proto_depIdxs,
+		MessageInfos:      file_conf_v1_config_with_sensitive_proto_msgTypes,
+	}.Build()
+	File_conf_v1_config_with_sensitive_proto = out.File
+	file_conf_v1_config_with_sensitive_proto_rawDesc = nil
+	file_conf_v1_config_with_sensitive_proto_goTypes = nil
+	file_conf_v1_config_with_sensitive_proto_depIdxs = nil
+}
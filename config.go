@@ -1,25 +1,41 @@
 package protoconf
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/bufbuild/protovalidate-go"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+
+	"github.com/gosynergy/protoconf/merge"
 )
 
-var ErrNoProvider = errors.New("no provider")
+var (
+	ErrNoProvider    = errors.New("no provider")
+	ErrUnknownFormat = errors.New("unknown configuration format")
+)
 
 // ConfigLoader is a configuration loader.
 type ConfigLoader struct {
 	opts      options
 	validator *protovalidate.Validator
-	values    map[string]interface{}
+
+	mu          sync.RWMutex
+	values      map[string]interface{}
+	origins     map[string]Origin
+	errs        []*ConfigError
+	current     proto.Message
+	subscribers []ChangeFunc
+
+	// reloadMu serializes the reloads triggered by the watchers.
+	reloadMu sync.Mutex
 }
 
-var _ Loader = (*ConfigLoader)(nil)
+var _ ContextLoader = (*ConfigLoader)(nil)
 
 // Loader contains the methods to load and scan a configuration.
 type Loader interface {
@@ -27,6 +43,20 @@ type Loader interface {
 	Scan(message proto.Message) error
 }
 
+// ContextLoader is a Loader supporting cancellation.
+type ContextLoader interface {
+	Loader
+	LoadContext(ctx context.Context) error
+}
+
+// loaded is a loaded configuration.
+type loaded struct {
+	values  map[string]interface{}
+	origins map[string]Origin
+	// errs are the errors collected while loading, see WithCollectErrors.
+	errs []*ConfigError
+}
+
 // New creates a new ConfigLoader.
 func New(opts ...Option) (*ConfigLoader, error) {
 	confOpts := options{}
@@ -34,10 +64,18 @@ func New(opts ...Option) (*ConfigLoader, error) {
 		opt(&confOpts)
 	}
 
-	if confOpts.provider == nil {
+	if len(confOpts.layers) == 0 {
 		return nil, ErrNoProvider
 	}
 
+	if confOpts.merger == nil {
+		confOpts.merger = merge.New()
+	}
+
+	if !confOpts.registrySet {
+		confOpts.registry = DefaultRegistry()
+	}
+
 	validator, err := protovalidate.New()
 	if err != nil {
 		return nil, fmt.Errorf("protovalidate new: %w", err)
@@ -49,88 +87,259 @@ func New(opts ...Option) (*ConfigLoader, error) {
 	}, nil
 }
 
-// Scan unmarshall the configuration into the provided message and validates it.
+// Scan unmarshall the configuration into the provided message, applies the field defaults and validates it.
 func (c *ConfigLoader) Scan(message proto.Message) error {
+	c.mu.RLock()
+	config := &loaded{
+		values:  c.values,
+		origins: c.origins,
+		errs:    c.errs,
+	}
+	c.mu.RUnlock()
+
+	return c.scan(config, message)
+}
+
+// Load reads and parses the configuration from the providers, merges it and applies the transformers.
+func (c *ConfigLoader) Load() error {
+	return c.LoadContext(context.Background())
+}
+
+// LoadContext is Load with a context. Providers and transformers implementing
+// ContextProvider and ContextTransformer receive the context. Other providers
+// and transformers are not interrupted, but LoadContext returns as soon as
+// the context is done.
+func (c *ConfigLoader) LoadContext(ctx context.Context) error {
+	config, err := c.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.values = config.values
+	c.origins = config.origins
+	c.errs = config.errs
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *ConfigLoader) load(ctx context.Context) (*loaded, error) {
 	var err error
 
-	err = c.unmarshal(message)
+	config, err := c.parse(ctx)
 	if err != nil {
-		return fmt.Errorf("unmarshal config: %w", err)
+		return nil, fmt.Errorf("parse config: %w", err)
 	}
 
-	err = c.validator.Validate(message)
+	err = c.transform(ctx, config)
 	if err != nil {
-		return fmt.Errorf("validate: %w", err)
+		return nil, fmt.Errorf("transform config: %w", err)
 	}
 
-	return nil
+	return config, nil
 }
 
-// Load reads and parses the configuration from the provider and applies the transformers.
-func (c *ConfigLoader) Load() error {
+func (c *ConfigLoader) scan(config *loaded, message proto.Message) error {
 	var err error
 
-	err = c.parse()
+	if c.opts.collectErrors {
+		return c.scanAll(config, message)
+	}
+
+	err = c.unmarshal(config, message)
 	if err != nil {
-		return fmt.Errorf("parse config: %w", err)
+		return fmt.Errorf("unmarshal config: %w", err)
 	}
 
-	err = c.transform()
+	err = applyDefaults(message.ProtoReflect())
 	if err != nil {
-		return fmt.Errorf("transform config: %w", err)
+		return fmt.Errorf("apply defaults: %w", err)
+	}
+
+	err = c.validator.Validate(message)
+	if err != nil {
+		return fmt.Errorf("validate: %w", errors.Join(violationErrors(err, config.origins)...))
 	}
 
 	return nil
 }
 
-func (c *ConfigLoader) parse() error {
-	var err error
+func (c *ConfigLoader) parse(ctx context.Context) (*loaded, error) {
+	config := &loaded{
+		values:  make(map[string]interface{}),
+		origins: make(map[string]Origin),
+	}
 
-	if c.opts.parser == nil {
-		c.values, err = c.opts.provider.Read()
+	for i, l := range c.opts.layers {
+		err := c.readLayer(ctx, config, l)
 		if err != nil {
-			return fmt.Errorf("read config: %w", err)
+			return nil, fmt.Errorf("layer %d: %w", i, err)
 		}
+	}
+
+	pruneOrigins(config.origins, config.values)
 
-		return nil
+	return config, nil
+}
+
+func (c *ConfigLoader) read(ctx context.Context, l layer) (map[string]interface{}, map[string]Position, error) {
+	parser := l.parser
+	if parser == nil {
+		parser = c.opts.parser
 	}
 
-	data, err := c.opts.provider.ReadBytes()
+	if parser == nil && c.opts.registry != nil {
+		return c.readDetected(ctx, l.provider)
+	}
+
+	if parser == nil {
+		values, err := readContext(ctx, l.provider)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read config: %w", err)
+		}
+
+		return values, nil, nil
+	}
+
+	data, err := readBytesContext(ctx, l.provider)
 	if err != nil {
-		return fmt.Errorf("read config bytes: %w", err)
+		return nil, nil, fmt.Errorf("read config bytes: %w", err)
+	}
+
+	return unmarshal(parser, data)
+}
+
+// readDetected reads the provider without a parser. The parser is selected
+// by the registry from the file extension or the content type of
+// the provider. The other providers are read as maps, like without
+// a registry, and their data is sniffed if they cannot be.
+func (c *ConfigLoader) readDetected(
+	ctx context.Context,
+	provider Provider,
+) (map[string]interface{}, map[string]Position, error) {
+	fileProvider, isFile := provider.(FileProvider)
+	if isFile {
+		parser, ok := c.opts.registry.ByExtension(fileProvider.Path())
+		if ok {
+			data, err := readBytesContext(ctx, provider)
+			if err != nil {
+				return nil, nil, fmt.Errorf("read config bytes: %w", err)
+			}
+
+			return unmarshal(parser, data)
+		}
 	}
 
-	c.values, err = c.opts.parser.Unmarshal(data)
+	contentTypeProvider, isTyped := provider.(ContentTypeProvider)
+
+	var readErr error
+
+	if !isFile && !isTyped {
+		values, err := readContext(ctx, provider)
+		if err == nil {
+			return values, nil, nil
+		}
+
+		if ctx.Err() != nil {
+			return nil, nil, fmt.Errorf("read config: %w", err)
+		}
+
+		readErr = err
+	}
+
+	data, err := readBytesContext(ctx, provider)
 	if err != nil {
-		return fmt.Errorf("parse config: %w", err)
+		return nil, nil, fmt.Errorf("read config bytes: %w", errors.Join(readErr, err))
 	}
 
-	return nil
+	var (
+		parser Parser
+		ok     bool
+	)
+
+	if isTyped {
+		parser, ok = c.opts.registry.ByContentType(contentTypeProvider.ContentType())
+	}
+
+	if !ok {
+		parser, ok = c.opts.registry.Sniff(data)
+	}
+
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: detected %s", ErrUnknownFormat, Detect(data))
+	}
+
+	return unmarshal(parser, data)
 }
 
-func (c *ConfigLoader) transform() error {
-	var err error
+// unmarshal parses the data, with the positions if the parser is
+// a PositionParser.
+func unmarshal(parser Parser, data []byte) (map[string]interface{}, map[string]Position, error) {
+	positionParser, ok := parser.(PositionParser)
+	if ok {
+		values, positions, err := positionParser.UnmarshalWithPositions(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse config: %w", err)
+		}
+
+		return values, positions, nil
+	}
+
+	values, err := parser.Unmarshal(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse config: %w", err)
+	}
 
+	return values, nil, nil
+}
+
+func (c *ConfigLoader) transform(ctx context.Context, config *loaded) error {
 	for _, t := range c.opts.transformers {
-		c.values, err = t.Transform(c.values)
+		before := flatten(config.values)
+
+		values, err := transformContext(ctx, t, config.values)
 		if err != nil {
-			return fmt.Errorf("transform config: %w", err)
+			err = c.collectTransformError(config, t, err)
+			if err != nil {
+				return fmt.Errorf("transform config: %w", err)
+			}
+
+			if values == nil {
+				continue
+			}
 		}
+
+		config.values = values
+
+		traceTransform(config.origins, before, flatten(values), name(t))
 	}
 
 	return nil
 }
 
-func (c *ConfigLoader) unmarshal(message proto.Message) error {
+func (c *ConfigLoader) unmarshal(config *loaded, message proto.Message) error {
 	var err error
 
-	data, err := json.Marshal(c.values)
+	if c.opts.strict {
+		errs := unknownFields(message.ProtoReflect().Descriptor(), config.values, "", config.origins)
+		if len(errs) > 0 {
+			return errors.Join(errs...)
+		}
+	}
+
+	data, err := json.Marshal(config.values)
 	if err != nil {
 		return fmt.Errorf("json marshal config: %w", err)
 	}
 
-	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, message)
+	err = protojson.UnmarshalOptions{DiscardUnknown: !c.opts.strict}.Unmarshal(data, message)
 	if err != nil {
+		errs := typeErrors(message.ProtoReflect().Descriptor(), config.values, "", config.origins)
+		if len(errs) > 0 {
+			return fmt.Errorf("protojson unmarshal config: %w", errors.Join(errs...))
+		}
+
 		return fmt.Errorf("protojson unmarshal config: %w", err)
 	}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/bufbuild/protovalidate-go"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/knadh/koanf/parsers/json"
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/file"
 	"github.com/stretchr/testify/mock"
@@ -193,6 +194,29 @@ func (s *ConfigTestSuite) TestLoadWithInvalidType() {
 	s.True(strings.Contains(err.Error(), "invalid google.protobuf.Duration"))
 }
 
+func (s *ConfigTestSuite) TestLoadWithLayers() {
+	loader, err := New(
+		WithProvider(file.Provider("conf/config.yaml")),
+		WithParser(yaml.Parser()),
+		WithLayer(file.Provider("conf/config-overlay.json"), json.Parser()),
+	)
+	s.Require().NoError(err)
+
+	err = loader.Load()
+	s.Require().NoError(err)
+
+	var cfg v1.Config
+	err = loader.Scan(&cfg)
+	s.Require().NoError(err)
+
+	s.Equal("0.0.0.0:80", cfg.GetServer().GetHttp().GetAddr())
+	s.Equal(int64(1), cfg.GetServer().GetHttp().GetTimeout().GetSeconds())
+	s.Equal("0.0.0.0:9000", cfg.GetServer().GetGrpc().GetAddr())
+	s.Equal("mysql", cfg.GetData().GetDatabase().GetDriver())
+	s.Equal(int64(1), cfg.GetData().GetRedis().GetReadTimeout().GetSeconds())
+	s.Equal(int32(200000000), cfg.GetData().GetRedis().GetWriteTimeout().GetNanos())
+}
+
 func (s *ConfigTestSuite) TestLoadWithoutProvider() {
 	_, err := New(
 		WithParser(yaml.Parser()),
@@ -220,6 +244,26 @@ func (s *ConfigTestSuite) TestLoadWithCustomTransformer() {
 	s.Require().NoError(err)
 }
 
+func (s *ConfigTestSuite) TestLoadWithCustomMerger() {
+	merger := NewMockMerger(s.T())
+	merger.
+		EXPECT().
+		Merge(mock.Anything, mock.Anything).
+		Twice().
+		Return(map[string]interface{}{}, nil)
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/config.yaml")),
+		WithProvider(file.Provider("conf/config-env-expand.yaml")),
+		WithParser(yaml.Parser()),
+		WithMerger(merger),
+	)
+	s.Require().NoError(err)
+
+	err = loader.Load()
+	s.Require().NoError(err)
+}
+
 func (s *ConfigTestSuite) TestLoadWithProviderWithoutParser() {
 	provider := NewMockProvider(s.T())
 	provider.EXPECT().
@@ -0,0 +1,68 @@
+package protoconf
+
+import (
+	"context"
+)
+
+func readContext(ctx context.Context, p Provider) (map[string]interface{}, error) {
+	ctxProvider, ok := p.(ContextProvider)
+	if ok {
+		return ctxProvider.ReadContext(ctx)
+	}
+
+	return withContext(ctx, p.Read)
+}
+
+func readBytesContext(ctx context.Context, p Provider) ([]byte, error) {
+	ctxProvider, ok := p.(ContextProvider)
+	if ok {
+		return ctxProvider.ReadBytesContext(ctx)
+	}
+
+	return withContext(ctx, p.ReadBytes)
+}
+
+func transformContext(
+	ctx context.Context,
+	t Transformer,
+	values map[string]interface{},
+) (map[string]interface{}, error) {
+	ctxTransformer, ok := t.(ContextTransformer)
+	if ok {
+		return ctxTransformer.TransformContext(ctx, values)
+	}
+
+	return withContext(ctx, func() (map[string]interface{}, error) {
+		return t.Transform(values)
+	})
+}
+
+// withContext calls fn and returns its result or the context error if
+// the context is done first. fn keeps running in the background then.
+func withContext[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	var zero T
+
+	err := ctx.Err()
+	if err != nil {
+		return zero, err
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+		value, err := fn()
+		done <- result{value: value, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-done:
+		return res.value, res.err
+	}
+}
@@ -0,0 +1,112 @@
+package protoconf
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type contextProvider struct {
+	*MockProvider
+	*MockContextProvider
+}
+
+type contextTransformer struct {
+	*MockTransformer
+	*MockContextTransformer
+}
+
+func TestConfigLoader_LoadContext_PrefersContextProvider(t *testing.T) {
+	t.Parallel()
+
+	provider := contextProvider{
+		MockProvider:        NewMockProvider(t),
+		MockContextProvider: NewMockContextProvider(t),
+	}
+	provider.MockContextProvider.
+		EXPECT().
+		ReadBytesContext(mock.Anything).
+		Return([]byte("a: 1"), nil)
+
+	transformer := contextTransformer{
+		MockTransformer:        NewMockTransformer(t),
+		MockContextTransformer: NewMockContextTransformer(t),
+	}
+	transformer.MockContextTransformer.
+		EXPECT().
+		TransformContext(mock.Anything, map[string]interface{}{"a": 1}).
+		Return(map[string]interface{}{}, nil)
+
+	loader, err := New(
+		WithProvider(provider),
+		WithParser(yaml.Parser()),
+		WithTransformers(transformer),
+	)
+	require.NoError(t, err)
+
+	err = loader.LoadContext(context.Background())
+	require.NoError(t, err)
+}
+
+func TestConfigLoader_LoadContext_Deadline(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	provider := NewMockProvider(t)
+	provider.
+		EXPECT().
+		Read().
+		RunAndReturn(func() (map[string]interface{}, error) {
+			<-release
+
+			return map[string]interface{}{}, nil
+		})
+
+	loader, err := New(
+		WithProvider(provider),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = loader.LoadContext(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestConfigLoader_LoadContext_TransformerDeadline(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	transformer := NewMockTransformer(t)
+	transformer.
+		EXPECT().
+		Transform(mock.Anything).
+		RunAndReturn(func(values map[string]interface{}) (map[string]interface{}, error) {
+			<-release
+
+			return values, nil
+		})
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/config.yaml")),
+		WithParser(yaml.Parser()),
+		WithTransformers(transformer),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = loader.LoadContext(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,156 @@
+package protoconf
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/gosynergy/protoconf/internal/schema"
+)
+
+// ApplyDefaults sets the `(protoconf.field).default` values of the fields of
+// the message which are not set, like Scan does.
+func ApplyDefaults(message proto.Message) error {
+	return applyDefaults(message.ProtoReflect())
+}
+
+// CheckDefaults checks the `(protoconf.field).default` values of every field
+// of the message and of its nested messages, whether their parent field has
+// a default or not.
+func CheckDefaults(desc protoreflect.MessageDescriptor) error {
+	return checkDefaults(desc, make(map[protoreflect.FullName]bool))
+}
+
+func checkDefaults(desc protoreflect.MessageDescriptor, checked map[protoreflect.FullName]bool) error {
+	if checked[desc.FullName()] || schema.IsWellKnownType(desc) {
+		return nil
+	}
+
+	checked[desc.FullName()] = true
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		err := setDefault(dynamicpb.NewMessage(desc), fd)
+		if err != nil {
+			return err
+		}
+
+		if fd.Message() != nil {
+			err = checkDefaults(fd.Message(), checked)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyDefaults sets the `(protoconf.field).default` values of the fields
+// which are not set. The defaults of the nested messages are applied if the
+// nested message is set.
+//
+// Fields without presence, e.g. proto3 scalars not marked as optional,
+// are considered not set when they hold the zero value.
+func applyDefaults(m protoreflect.Message) error {
+	var err error
+
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		if !m.Has(fd) && !isOneofSet(m, fd) {
+			err = setDefault(m, fd)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !m.Has(fd) || fd.Message() == nil || schema.IsWellKnownType(fd.Message()) {
+			continue
+		}
+
+		err = applyNestedDefaults(m.Get(fd), fd)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applyNestedDefaults(value protoreflect.Value, fd protoreflect.FieldDescriptor) error {
+	var err error
+
+	switch {
+	case fd.IsMap():
+		if fd.MapValue().Message() == nil || schema.IsWellKnownType(fd.MapValue().Message()) {
+			return nil
+		}
+
+		value.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+			err = applyDefaults(v.Message())
+
+			return err == nil
+		})
+	case fd.IsList():
+		list := value.List()
+		for i := 0; i < list.Len() && err == nil; i++ {
+			err = applyDefaults(list.Get(i).Message())
+		}
+	default:
+		err = applyDefaults(value.Message())
+	}
+
+	return err
+}
+
+func setDefault(m protoreflect.Message, fd protoreflect.FieldDescriptor) error {
+	def := schema.Options(fd).GetDefault()
+	if def == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(map[string]json.RawMessage{
+		fd.JSONName(): defaultJSON(fd, def),
+	})
+	if err != nil {
+		return fmt.Errorf("default of %s: %w", fd.FullName(), err)
+	}
+
+	tmp := m.New()
+
+	err = protojson.Unmarshal(data, tmp.Interface())
+	if err != nil {
+		return fmt.Errorf("default of %s: %w", fd.FullName(), err)
+	}
+
+	m.Set(fd, tmp.Get(fd))
+
+	return nil
+}
+
+// defaultJSON returns the JSON representation of the default value.
+// Singular strings and values which are not a valid JSON are quoted.
+func defaultJSON(fd protoreflect.FieldDescriptor, def string) json.RawMessage {
+	isString := fd.Kind() == protoreflect.StringKind && fd.Cardinality() != protoreflect.Repeated
+	if !isString && json.Valid([]byte(def)) {
+		return json.RawMessage(def)
+	}
+
+	quoted, _ := json.Marshal(def)
+
+	return quoted
+}
+
+func isOneofSet(m protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+	oneof := fd.ContainingOneof()
+
+	return oneof != nil && m.WhichOneof(oneof) != nil
+}
@@ -0,0 +1,82 @@
+package protoconf
+
+import (
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	t.Parallel()
+
+	var cfg v1.ConfigWithDefaults
+
+	require.NoError(t, protojson.Unmarshal([]byte(`{"log": {"json": false}}`), &cfg))
+	require.NoError(t, applyDefaults(cfg.ProtoReflect()))
+
+	expected := &v1.ConfigWithDefaults{
+		Server: &v1.ConfigWithDefaults_Server{
+			Addr:     ":9090",
+			Timeout:  &durationpb.Duration{Seconds: 1, Nanos: 500000000},
+			MaxConns: 100,
+			Hosts:    []string{"a", "b"},
+		},
+		Log: &v1.ConfigWithDefaults_Log{
+			Level: v1.ConfigWithDefaults_LEVEL_INFO,
+			Json:  proto.Bool(false),
+		},
+		Ratio: 0.5,
+	}
+	assert.True(t, proto.Equal(expected, &cfg), "got %v", &cfg)
+}
+
+func TestApplyDefaults_KeepsSetValues(t *testing.T) {
+	t.Parallel()
+
+	var cfg v1.ConfigWithDefaults
+
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"server": {"addr": ":80", "timeout": "3s", "hosts": ["c"]},
+		"ratio": 0.1
+	}`), &cfg))
+	require.NoError(t, applyDefaults(cfg.ProtoReflect()))
+
+	assert.Equal(t, ":80", cfg.GetServer().GetAddr())
+	assert.Equal(t, int64(3), cfg.GetServer().GetTimeout().GetSeconds())
+	assert.Equal(t, []string{"c"}, cfg.GetServer().GetHosts())
+	assert.Equal(t, uint32(100), cfg.GetServer().GetMaxConns())
+	assert.InDelta(t, 0.1, cfg.GetRatio(), 0)
+	assert.Nil(t, cfg.GetLog())
+}
+
+func (s *ConfigTestSuite) TestLoadWithDefaults() {
+	loader, err := New(
+		WithProvider(&memoryProvider{data: []byte("server: {http: {addr: 127.0.0.1:8080}}")}),
+		WithParser(yaml.Parser()),
+	)
+	s.Require().NoError(err)
+
+	err = loader.Load()
+	s.Require().NoError(err)
+
+	var cfg v1.Config
+	err = loader.Scan(&cfg)
+	s.Require().NoError(err)
+
+	s.Equal(int64(1), cfg.GetServer().GetHttp().GetTimeout().GetSeconds())
+	s.Nil(cfg.GetServer().GetGrpc())
+}
+
+func TestCheckDefaults(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, CheckDefaults((&v1.ConfigWithDefaults{}).ProtoReflect().Descriptor()))
+	require.NoError(t, CheckDefaults((&v1.Config{}).ProtoReflect().Descriptor()))
+}
@@ -0,0 +1,225 @@
+// Package docs generates the reference documentation of a configuration
+// message: every key with its type, default, description, validation
+// constraints, deprecation and environment variable or flag binding.
+package docs
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/gosynergy/protoconf/flags"
+	"github.com/gosynergy/protoconf/internal/schema"
+	"github.com/gosynergy/protoconf/provider/env"
+)
+
+// Message is the documentation of a configuration message.
+type Message struct {
+	// Name is the full name of the message, e.g. `conf.v1.Config`.
+	Name string
+	// Description is the leading comment of the message.
+	Description string
+	// Keys are the configuration keys in the declaration order.
+	Keys []Key
+}
+
+// Key is the documentation of a configuration key.
+type Key struct {
+	// Path is the dotted key, e.g. `server.http.addr`.
+	Path string
+	// Type is the proto type, e.g. `repeated string` or
+	// `google.protobuf.Duration`.
+	Type string
+	// Values are the names of the enum values.
+	Values []string
+	// Default is the `(protoconf.field).default` option.
+	Default string
+	// Description is the leading comment of the field.
+	Description string
+	// Constraints are the `(buf.validate.field)` constraints,
+	// e.g. `string.min_len = 3`.
+	Constraints []string
+	// Deprecated reports whether the field is deprecated.
+	Deprecated bool
+	// Env is the bound environment variable, see WithEnv.
+	Env string
+	// Flag is the bound command-line flag, see WithFlags.
+	Flag string
+}
+
+// Generate returns the documentation of the message. The comments are
+// only available when the descriptor has source info, e.g. when it is
+// read from a descriptor set built by `buf build -o`.
+func Generate(desc protoreflect.MessageDescriptor, opts ...Option) *Message {
+	docOpts := options{}
+	for _, opt := range opts {
+		opt(&docOpts)
+	}
+
+	envNames := make(map[string]string)
+	if docOpts.env {
+		for _, binding := range env.Provider(desc, docOpts.envOpts...).Bindings() {
+			envNames[binding.Path] = binding.Name
+		}
+	}
+
+	flagNames := make(map[string]string)
+	if docOpts.flags {
+		fs := flag.NewFlagSet(string(desc.FullName()), flag.ContinueOnError)
+		for _, binding := range flags.Register(fs, desc, docOpts.flagsOpts...).Bindings() {
+			flagNames[binding.Path] = "--" + binding.Name
+		}
+	}
+
+	message := &Message{
+		Name:        string(desc.FullName()),
+		Description: schema.Comments(desc),
+	}
+
+	for _, leaf := range schema.Leaves(desc) {
+		fd := leaf.Field()
+		path := leaf.Path()
+
+		message.Keys = append(message.Keys, Key{
+			Path:        path,
+			Type:        fieldType(fd),
+			Values:      enumValues(fd),
+			Default:     schema.Options(fd).GetDefault(),
+			Description: schema.Comments(fd),
+			Constraints: constraints(schema.Constraints(fd)),
+			Deprecated:  schema.Deprecated(fd),
+			Env:         envNames[path],
+			Flag:        flagNames[path],
+		})
+	}
+
+	return message
+}
+
+func fieldType(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.IsMap():
+		return fmt.Sprintf("map<%s, %s>", singularType(fd.MapKey()), singularType(fd.MapValue()))
+	case fd.IsList():
+		return "repeated " + singularType(fd)
+	default:
+		return singularType(fd)
+	}
+}
+
+func singularType(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		return string(fd.Enum().FullName())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(fd.Message().FullName())
+	default:
+		return fd.Kind().String()
+	}
+}
+
+func enumValues(fd protoreflect.FieldDescriptor) []string {
+	if fd.IsMap() {
+		fd = fd.MapValue()
+	}
+
+	if fd.Enum() == nil {
+		return nil
+	}
+
+	values := fd.Enum().Values()
+	names := make([]string, 0, values.Len())
+
+	for i := 0; i < values.Len(); i++ {
+		names = append(names, string(values.Get(i).Name()))
+	}
+
+	return names
+}
+
+// constraints renders the set constraint fields in the declaration order as
+// `path = value`, and the boolean rules as their path, e.g. `required` or
+// `string.email`.
+func constraints(msg protoreflect.ProtoMessage) []string {
+	if msg == nil {
+		return nil
+	}
+
+	var rules []string
+
+	var walk func(path string, m protoreflect.Message)
+	walk = func(path string, m protoreflect.Message) {
+		fields := m.Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			if !m.Has(fd) {
+				continue
+			}
+
+			value := m.Get(fd)
+
+			fieldPath := string(fd.Name())
+			if path != "" {
+				fieldPath = path + "." + fieldPath
+			}
+
+			switch {
+			case fd.Message() != nil && !fd.IsList() && !schema.IsWellKnownType(fd.Message()):
+				walk(fieldPath, value.Message())
+			case fd.Kind() == protoreflect.BoolKind && value.Bool():
+				rules = append(rules, fieldPath)
+			default:
+				rules = append(rules, fieldPath+" = "+formatValue(fd, value))
+			}
+		}
+	}
+
+	walk("", msg.ProtoReflect())
+
+	return rules
+}
+
+func formatValue(fd protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	if fd.IsList() {
+		list := value.List()
+		elems := make([]string, 0, list.Len())
+
+		for i := 0; i < list.Len(); i++ {
+			elems = append(elems, formatSingular(fd, list.Get(i)))
+		}
+
+		return "[" + strings.Join(elems, ", ") + "]"
+	}
+
+	return formatSingular(fd, value)
+}
+
+func formatSingular(fd protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return fmt.Sprintf("%q", value.String())
+	case protoreflect.EnumKind:
+		enumValue := fd.Enum().Values().ByNumber(value.Enum())
+		if enumValue != nil {
+			return string(enumValue.Name())
+		}
+
+		return value.String()
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return formatMessage(value.Message())
+	default:
+		return value.String()
+	}
+}
+
+func formatMessage(m protoreflect.Message) string {
+	data, err := protojson.Marshal(m.Interface())
+	if err != nil {
+		return string(m.Descriptor().FullName())
+	}
+
+	return string(data)
+}
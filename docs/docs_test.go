@@ -0,0 +1,137 @@
+package docs
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+	"github.com/gosynergy/protoconf/flags"
+	"github.com/gosynergy/protoconf/provider/env"
+)
+
+func findMessage(t *testing.T, name protoreflect.FullName) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	data, err := os.ReadFile("../conf/config.binpb")
+	require.NoError(t, err)
+
+	var set descriptorpb.FileDescriptorSet
+	require.NoError(t, proto.Unmarshal(data, &set))
+
+	files, err := protodesc.NewFiles(&set)
+	require.NoError(t, err)
+
+	desc, err := files.FindDescriptorByName(name)
+	require.NoError(t, err)
+
+	return desc.(protoreflect.MessageDescriptor)
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	message := Generate((&v1.ConfigWithConstraints{}).ProtoReflect().Descriptor())
+
+	assert.Equal(t, "conf.v1.ConfigWithConstraints", message.Name)
+
+	keys := make(map[string]Key)
+	for _, key := range message.Keys {
+		keys[key.Path] = key
+	}
+
+	assert.Equal(t, Key{
+		Path: "name",
+		Type: "string",
+		Constraints: []string{
+			"required",
+			"string.min_len = 3",
+			"string.max_len = 32",
+			`string.pattern = "^[a-z-]+$"`,
+		},
+	}, keys["name"])
+	assert.Equal(t, Key{
+		Path:        "mode",
+		Type:        "conf.v1.ConfigWithConstraints.Mode",
+		Values:      []string{"MODE_UNSPECIFIED", "MODE_DEV", "MODE_PROD"},
+		Constraints: []string{"enum.in = [1, 2]"},
+	}, keys["mode"])
+	assert.Equal(t, []string{
+		"repeated.min_items = 1",
+		"repeated.unique",
+		"repeated.items.string.hostname",
+	}, keys["hosts"].Constraints)
+	assert.Equal(t, "map<string, int64>", keys["limits"].Type)
+	assert.Equal(t, "google.protobuf.UInt32Value", keys["retries"].Type)
+	assert.True(t, keys["legacy"].Deprecated)
+}
+
+func TestGenerate_Defaults(t *testing.T) {
+	t.Parallel()
+
+	message := Generate((&v1.ConfigWithDefaults{}).ProtoReflect().Descriptor())
+
+	defaults := make(map[string]string)
+	for _, key := range message.Keys {
+		defaults[key.Path] = key.Default
+	}
+
+	assert.Equal(t, map[string]string{
+		"server.addr":      ":8080",
+		"server.timeout":   "1.5s",
+		"server.max_conns": "100",
+		"server.hosts":     `["a", "b"]`,
+		"log.level":        "LEVEL_INFO",
+		"log.json":         "true",
+		"ratio":            "0.5",
+	}, defaults)
+}
+
+func TestMessage_Markdown(t *testing.T) {
+	t.Parallel()
+
+	message := Generate(
+		findMessage(t, "conf.v1.Config.Server"),
+		WithEnv(env.WithPrefix("APP")),
+		WithFlags(flags.WithPrefix("config")),
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, message.Markdown(&buf))
+
+	assert.Equal(t, "# conf.v1.Config.Server\n\n"+
+		"| Key | Type | Default | Description | Constraints | Env | Flag |\n"+
+		"|-----|------|---------|-------------|-------------|-----|------|\n"+
+		"| `http.addr` | `string` |  | HTTP listen address. |  | `APP_HTTP_ADDR` | `--config.http.addr` |\n"+
+		"| `http.timeout` | `google.protobuf.Duration` | `1s` | HTTP request timeout. |  | "+
+		"`APP_HTTP_TIMEOUT` | `--config.http.timeout` |\n"+
+		"| `grpc.addr` | `string` |  | gRPC listen address. |  | `APP_GRPC_ADDR` | `--config.grpc.addr` |\n"+
+		"| `grpc.timeout` | `google.protobuf.Duration` | `1s` | gRPC request timeout. |  | "+
+		"`APP_GRPC_TIMEOUT` | `--config.grpc.timeout` |\n",
+		buf.String())
+}
+
+func TestMessage_HTML(t *testing.T) {
+	t.Parallel()
+
+	message := Generate(findMessage(t, "conf.v1.ConfigWithConstraints"))
+
+	var buf bytes.Buffer
+	require.NoError(t, message.HTML(&buf))
+
+	html := buf.String()
+
+	assert.Contains(t, html, "<h1>conf.v1.ConfigWithConstraints</h1>\n"+
+		"<p>ConfigWithConstraints is a configuration with common validation constraints.</p>")
+	assert.Contains(t, html, "<td><del><code>legacy</code></del></td>")
+	assert.Contains(t, html, "<td>Deprecated.</td>")
+	assert.Contains(t, html, "<code>string.in = [&#34;eu&#34;, &#34;us&#34;]</code>")
+	assert.NotContains(t, html, "<th>Env</th>")
+}
@@ -0,0 +1,35 @@
+package docs
+
+import (
+	"github.com/gosynergy/protoconf/flags"
+	"github.com/gosynergy/protoconf/provider/env"
+)
+
+// Option is generator option.
+type Option func(*options)
+
+type options struct {
+	env     bool
+	envOpts []env.Option
+
+	flags     bool
+	flagsOpts []flags.Option
+}
+
+// WithEnv documents the environment variables bound to the keys by
+// the env provider created with the options.
+func WithEnv(opts ...env.Option) Option {
+	return func(o *options) {
+		o.env = true
+		o.envOpts = opts
+	}
+}
+
+// WithFlags documents the command-line flags bound to the keys by
+// flags.Register with the options.
+func WithFlags(opts ...flags.Option) Option {
+	return func(o *options) {
+		o.flags = true
+		o.flagsOpts = opts
+	}
+}
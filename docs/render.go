@@ -0,0 +1,131 @@
+package docs
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"text/template"
+)
+
+const markdownTemplate = `# {{ .Name }}
+{{ with .Description }}
+{{ . }}
+{{ end }}
+| Key | Type | Default | Description | Constraints |{{ if .HasEnv }} Env |{{ end }}{{ if .HasFlag }} Flag |{{ end }}
+|-----|------|---------|-------------|-------------|{{ if .HasEnv }}-----|{{ end }}{{ if .HasFlag }}------|{{ end }}
+{{- range .Keys }}
+| {{ if .Deprecated }}~~` + "`{{ .Path }}`" + `~~{{ else }}` + "`{{ .Path }}`" + `{{ end }} | ` + "`{{ typeOf . | cell }}`" + ` | {{ with .Default }}` + "`{{ cell . }}`" + `{{ end }} | {{ description . | cell }} | {{ range $i, $c := .Constraints }}{{ if $i }}<br>{{ end }}` + "`{{ cell $c }}`" + `{{ end }} |{{ if $.HasEnv }} {{ with .Env }}` + "`{{ . }}`" + `{{ end }} |{{ end }}{{ if $.HasFlag }} {{ with .Flag }}` + "`{{ . }}`" + `{{ end }} |{{ end }}
+{{- end }}
+`
+
+const htmlTemplate = `<h1>{{ .Name }}</h1>
+{{- with .Description }}
+<p>{{ . }}</p>
+{{- end }}
+<table>
+  <thead>
+    <tr>
+      <th>Key</th>
+      <th>Type</th>
+      <th>Default</th>
+      <th>Description</th>
+      <th>Constraints</th>
+      {{- if .HasEnv }}
+      <th>Env</th>
+      {{- end }}
+      {{- if .HasFlag }}
+      <th>Flag</th>
+      {{- end }}
+    </tr>
+  </thead>
+  <tbody>
+    {{- range .Keys }}
+    <tr>
+      <td>{{ if .Deprecated }}<del><code>{{ .Path }}</code></del>{{ else }}<code>{{ .Path }}</code>{{ end }}</td>
+      <td>{{ typeOf . }}</td>
+      <td>{{ with .Default }}<code>{{ . }}</code>{{ end }}</td>
+      <td>{{ description . }}</td>
+      <td>{{ range $i, $c := .Constraints }}{{ if $i }}<br>{{ end }}<code>{{ $c }}</code>{{ end }}</td>
+      {{- if $.HasEnv }}
+      <td>{{ with .Env }}<code>{{ . }}</code>{{ end }}</td>
+      {{- end }}
+      {{- if $.HasFlag }}
+      <td>{{ with .Flag }}<code>{{ . }}</code>{{ end }}</td>
+      {{- end }}
+    </tr>
+    {{- end }}
+  </tbody>
+</table>
+`
+
+var funcs = map[string]interface{}{
+	"typeOf":      typeOf,
+	"description": description,
+	"cell":        cell,
+}
+
+var (
+	markdown = template.Must(template.New("markdown").Funcs(funcs).Parse(markdownTemplate))
+	html     = htmltemplate.Must(htmltemplate.New("html").Funcs(funcs).Parse(htmlTemplate))
+)
+
+// view is the template data.
+type view struct {
+	*Message
+
+	HasEnv  bool
+	HasFlag bool
+}
+
+// Markdown writes the documentation as a Markdown table.
+func (m *Message) Markdown(w io.Writer) error {
+	err := markdown.Execute(w, m.view())
+	if err != nil {
+		return fmt.Errorf("execute markdown template: %w", err)
+	}
+
+	return nil
+}
+
+// HTML writes the documentation as an HTML table.
+func (m *Message) HTML(w io.Writer) error {
+	err := html.Execute(w, m.view())
+	if err != nil {
+		return fmt.Errorf("execute html template: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Message) view() view {
+	v := view{Message: m}
+
+	for _, key := range m.Keys {
+		v.HasEnv = v.HasEnv || key.Env != ""
+		v.HasFlag = v.HasFlag || key.Flag != ""
+	}
+
+	return v
+}
+
+func typeOf(key Key) string {
+	if len(key.Values) == 0 {
+		return key.Type
+	}
+
+	return key.Type + " (" + strings.Join(key.Values, ", ") + ")"
+}
+
+func description(key Key) string {
+	if !key.Deprecated {
+		return key.Description
+	}
+
+	return strings.TrimSpace("Deprecated. " + key.Description)
+}
+
+// cell escapes the pipes of a Markdown table cell.
+func cell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
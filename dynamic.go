@@ -0,0 +1,64 @@
+package protoconf
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var ErrNotMessage = errors.New("not a message")
+
+// FindMessage returns the descriptor of the message with the fully
+// qualified name, e.g. `conf.v1.Config`.
+func FindMessage(files *protoregistry.Files, name protoreflect.FullName) (protoreflect.MessageDescriptor, error) {
+	desc, err := files.FindDescriptorByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("find message %q: %w", name, err)
+	}
+
+	msgDesc, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%q: %w", name, ErrNotMessage)
+	}
+
+	return msgDesc, nil
+}
+
+// ScanDynamic is Scan into a new dynamic message of the message with the
+// fully qualified name. It allows to load a configuration without the
+// generated code of its message, e.g. from a descriptor set built by
+// `buf build -o`.
+func (c *ConfigLoader) ScanDynamic(files *protoregistry.Files, name protoreflect.FullName) (*dynamicpb.Message, error) {
+	desc, err := FindMessage(files, name)
+	if err != nil {
+		return nil, err
+	}
+
+	message := dynamicpb.NewMessage(desc)
+
+	err = c.Scan(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// ScanDescriptorSet is ScanDynamic with the files of the descriptor set.
+// The set must include the imports of the message.
+func (c *ConfigLoader) ScanDescriptorSet(
+	set *descriptorpb.FileDescriptorSet,
+	name protoreflect.FullName,
+) (*dynamicpb.Message, error) {
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("create files: %w", err)
+	}
+
+	return c.ScanDynamic(files, name)
+}
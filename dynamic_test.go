@@ -0,0 +1,90 @@
+package protoconf
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/gosynergy/protoconf/provider/file"
+	"github.com/gosynergy/protoconf/transform/expandenv"
+)
+
+func readDescriptorSet(t *testing.T) *descriptorpb.FileDescriptorSet {
+	t.Helper()
+
+	data, err := os.ReadFile("conf/config.binpb")
+	require.NoError(t, err)
+
+	var set descriptorpb.FileDescriptorSet
+	require.NoError(t, proto.Unmarshal(data, &set))
+
+	return &set
+}
+
+func TestConfigLoader_ScanDescriptorSet(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/config.yaml")),
+		WithParser(yaml.Parser()),
+		WithTransformers(expandenv.NewTransformer()),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	message, err := loader.ScanDescriptorSet(readDescriptorSet(t), "conf.v1.ConfigWithValidate")
+	require.NoError(t, err)
+
+	fields := message.Descriptor().Fields()
+	server := message.Get(fields.ByName("server")).Message()
+	http := server.Get(server.Descriptor().Fields().ByName("http")).Message()
+
+	assert.Equal(t, "127.0.0.1:8080", http.Get(http.Descriptor().Fields().ByName("addr")).String())
+}
+
+func TestConfigLoader_ScanDynamicValidationError(t *testing.T) {
+	t.Parallel()
+
+	files, err := protodesc.NewFiles(readDescriptorSet(t))
+	require.NoError(t, err)
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/invalid-config.yaml")),
+		WithParser(linePositionParser{yaml.Parser()}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	_, err = loader.ScanDynamic(files, "conf.v1.ConfigWithValidate")
+	require.Error(t, err)
+
+	var configErr *ConfigError
+
+	require.True(t, errors.As(err, &configErr))
+	assert.Equal(t, KindViolation, configErr.Kind)
+	assert.Equal(t, "server.http.addr", configErr.Path)
+}
+
+func TestFindMessage(t *testing.T) {
+	t.Parallel()
+
+	files, err := protodesc.NewFiles(readDescriptorSet(t))
+	require.NoError(t, err)
+
+	desc, err := FindMessage(files, "conf.v1.Config.Server")
+	require.NoError(t, err)
+	assert.Equal(t, "Server", string(desc.Name()))
+
+	_, err = FindMessage(files, "conf.v1.Missing")
+	require.Error(t, err)
+
+	_, err = FindMessage(files, "conf.v1.Config.server")
+	require.ErrorIs(t, err, ErrNotMessage)
+}
@@ -0,0 +1,222 @@
+package protoconf
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bufbuild/protovalidate-go"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/gosynergy/protoconf/internal/schema"
+)
+
+// ConfigError is an error of a configuration value located in its source.
+type ConfigError struct {
+	// Kind is the pipeline stage which reported the error.
+	Kind ErrorKind
+	// File is the file the value is read from, if known.
+	File string
+	// Line is the 1-based line of the value in the file, if known.
+	Line int
+	// Column is the 1-based column of the value in the file, if known.
+	Column int
+	// Path is the dotted field path, e.g. `server.http.addr`.
+	Path string
+	// ConstraintID is the protovalidate constraint of a violation,
+	// e.g. `required`.
+	ConstraintID string
+	// Message describes the error.
+	Message string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error renders the error in the compiler style:
+//
+//	conf/config.yaml:3:11: server.http.addr: value is required [required]
+func (e *ConfigError) Error() string {
+	var builder strings.Builder
+
+	if e.File != "" {
+		builder.WriteString(e.File)
+
+		if e.Line > 0 {
+			builder.WriteString(":" + strconv.Itoa(e.Line) + ":" + strconv.Itoa(e.Column))
+		}
+
+		builder.WriteString(": ")
+	}
+
+	if e.Path != "" {
+		builder.WriteString(e.Path + ": ")
+	}
+
+	builder.WriteString(e.Message)
+
+	if e.ConstraintID != "" {
+		builder.WriteString(" [" + e.ConstraintID + "]")
+	}
+
+	return builder.String()
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+func newConfigError(path string, origins map[string]Origin) *ConfigError {
+	configErr := &ConfigError{
+		Path: path,
+	}
+
+	origin, ok := explain(origins, path)
+	if ok {
+		configErr.File = origin.File
+		configErr.Line = origin.Position.Line
+		configErr.Column = origin.Position.Column
+	}
+
+	return configErr
+}
+
+// violationErrors returns a ConfigError for every violation of
+// the protovalidate.ValidationError. Other errors are returned as is.
+func violationErrors(err error, origins map[string]Origin) []error {
+	var validationErr *protovalidate.ValidationError
+	if !errors.As(err, &validationErr) {
+		return []error{err}
+	}
+
+	errs := make([]error, 0, len(validationErr.Violations))
+
+	for _, violation := range validationErr.Violations {
+		configErr := newConfigError(violation.GetFieldPath(), origins)
+		configErr.Kind = KindViolation
+		configErr.ConstraintID = violation.GetConstraintId()
+		configErr.Message = violation.GetMessage()
+		configErr.Err = validationErr
+
+		errs = append(errs, configErr)
+	}
+
+	return errs
+}
+
+// typeErrors returns a ConfigError for every value which cannot be
+// unmarshalled into its field. The error is reported for the innermost
+// invalid value. The errors of the sensitive fields do not include the value.
+func typeErrors(
+	desc protoreflect.MessageDescriptor,
+	values map[string]interface{},
+	path string,
+	origins map[string]Origin,
+) []error {
+	var errs []error
+
+	for _, key := range sortedKeys(values) {
+		fd := schema.FieldByKey(desc, key)
+		if fd == nil {
+			continue
+		}
+
+		keyPath := joinPath(path, key)
+
+		err := unmarshalField(desc, key, values[key])
+		if err == nil {
+			continue
+		}
+
+		nestedErrs := nestedTypeErrors(fd, values[key], keyPath, origins)
+		if len(nestedErrs) > 0 {
+			errs = append(errs, nestedErrs...)
+
+			continue
+		}
+
+		configErr := newConfigError(keyPath, origins)
+		configErr.Kind = KindType
+		configErr.Message = protojsonMessage(err)
+		configErr.Err = err
+
+		if schema.Options(fd).GetSensitive() {
+			// the protojson error includes the value
+			configErr.Message = "invalid value"
+			configErr.Err = nil
+		}
+
+		errs = append(errs, configErr)
+	}
+
+	return errs
+}
+
+func nestedTypeErrors(
+	fd protoreflect.FieldDescriptor,
+	value interface{},
+	path string,
+	origins map[string]Origin,
+) []error {
+	var errs []error
+
+	switch {
+	case fd.IsMap():
+		entries, ok := value.(map[string]interface{})
+		if !ok || !isMessageField(fd.MapValue()) {
+			return nil
+		}
+
+		for _, key := range sortedKeys(entries) {
+			entry, ok := entries[key].(map[string]interface{})
+			if ok {
+				errs = append(errs, typeErrors(fd.MapValue().Message(), entry, joinPath(path, key), origins)...)
+			}
+		}
+	case fd.IsList():
+		elems, ok := value.([]interface{})
+		if !ok || !isMessageField(fd) {
+			return nil
+		}
+
+		for i, elem := range elems {
+			elemValues, ok := elem.(map[string]interface{})
+			if ok {
+				elemPath := path + "[" + strconv.Itoa(i) + "]"
+				errs = append(errs, typeErrors(fd.Message(), elemValues, elemPath, origins)...)
+			}
+		}
+	default:
+		nested, ok := value.(map[string]interface{})
+		if ok && isMessageField(fd) {
+			errs = typeErrors(fd.Message(), nested, path, origins)
+		}
+	}
+
+	return errs
+}
+
+func isMessageField(fd protoreflect.FieldDescriptor) bool {
+	return fd.Message() != nil && !schema.IsWellKnownType(fd.Message())
+}
+
+// unmarshalField unmarshals the single field value into a new message.
+func unmarshalField(desc protoreflect.MessageDescriptor, key string, value interface{}) error {
+	data, err := json.Marshal(map[string]interface{}{key: value})
+	if err != nil {
+		return err
+	}
+
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, dynamicpb.NewMessage(desc))
+}
+
+var protojsonPrefix = regexp.MustCompile(`^proto:[\s\x{00a0}]*(\(line \d+:\d+\):[\s\x{00a0}]*)?`)
+
+// protojsonMessage returns the protojson error message without the position
+// in the generated JSON document.
+func protojsonMessage(err error) string {
+	return protojsonPrefix.ReplaceAllString(err.Error(), "")
+}
@@ -0,0 +1,144 @@
+package protoconf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+	"github.com/gosynergy/protoconf/provider/file"
+	"github.com/gosynergy/protoconf/transform/expandenv"
+)
+
+func TestConfigError_Error(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      *ConfigError
+		expected string
+	}{
+		{
+			name: "full",
+			err: &ConfigError{
+				File: "conf/config.yaml", Line: 3, Column: 11,
+				Path: "server.http.addr", ConstraintID: "required", Message: "value is required",
+			},
+			expected: "conf/config.yaml:3:11: server.http.addr: value is required [required]",
+		},
+		{
+			name:     "without position",
+			err:      &ConfigError{File: "conf/config.yaml", Path: "server", Message: "invalid"},
+			expected: "conf/config.yaml: server: invalid",
+		},
+		{
+			name:     "without file",
+			err:      &ConfigError{Path: "server", Message: "invalid"},
+			expected: "server: invalid",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.err.Error())
+		})
+	}
+}
+
+func TestConfigLoader_ScanTypeError(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/invalid-type-config.yaml")),
+		WithParser(linePositionParser{yaml.Parser()}),
+		WithTransformers(expandenv.NewTransformer()),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.Config
+	err = loader.Scan(&cfg)
+	require.Error(t, err)
+
+	var configErr *ConfigError
+
+	require.True(t, errors.As(err, &configErr))
+	assert.Equal(t, "conf/invalid-type-config.yaml", configErr.File)
+	assert.Equal(t, 4, configErr.Line)
+	assert.Equal(t, 5, configErr.Column)
+	assert.Equal(t, "server.http.timeout", configErr.Path)
+	assert.Equal(t, `invalid google.protobuf.Duration value "invalid"`, configErr.Message)
+	assert.Contains(t, err.Error(),
+		`conf/invalid-type-config.yaml:4:5: server.http.timeout: invalid google.protobuf.Duration value "invalid"`)
+}
+
+func TestConfigLoader_ScanValidationError(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/invalid-config.yaml")),
+		WithParser(linePositionParser{yaml.Parser()}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.ConfigWithValidate
+	err = loader.Scan(&cfg)
+	require.Error(t, err)
+
+	var configErr *ConfigError
+
+	require.True(t, errors.As(err, &configErr))
+	assert.Equal(t, &ConfigError{
+		Kind:         KindViolation,
+		File:         "conf/invalid-config.yaml",
+		Line:         3,
+		Column:       5,
+		Path:         "server.http.addr",
+		ConstraintID: "required",
+		Message:      "value is required",
+		Err:          configErr.Err,
+	}, configErr)
+}
+
+func TestTypeErrors_Nested(t *testing.T) {
+	t.Parallel()
+
+	desc := (&v1.ConfigWithMerge{}).ProtoReflect().Descriptor()
+	errs := typeErrors(desc, map[string]interface{}{
+		"listeners": []interface{}{
+			map[string]interface{}{"name": "http"},
+			map[string]interface{}{"name": 1},
+		},
+		"tags": "a",
+	}, "", nil)
+
+	require.Len(t, errs, 2)
+
+	var configErr *ConfigError
+
+	require.True(t, errors.As(errs[0], &configErr))
+	assert.Equal(t, "listeners[1].name", configErr.Path)
+
+	require.True(t, errors.As(errs[1], &configErr))
+	assert.Equal(t, "tags", configErr.Path)
+}
+
+func TestTypeErrors_Sensitive(t *testing.T) {
+	t.Parallel()
+
+	desc := (&v1.ConfigWithSensitive{}).ProtoReflect().Descriptor()
+	errs := typeErrors(desc, map[string]interface{}{
+		"private_key": 1234,
+	}, "", nil)
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, "private_key: invalid value", errs[0].Error())
+}
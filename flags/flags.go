@@ -0,0 +1,161 @@
+// Package flags binds command-line flags to the fields of a configuration
+// message.
+package flags
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/gosynergy/protoconf/internal/convert"
+	"github.com/gosynergy/protoconf/internal/schema"
+)
+
+// Binding binds a command-line flag to a configuration key.
+type Binding struct {
+	// Name is the flag name, e.g. `data.redis.read-timeout`.
+	Name string
+	// Path is the dotted configuration key, e.g. `data.redis.read_timeout`.
+	Path string
+
+	leaf  schema.Leaf
+	value *value
+}
+
+// Flags is a provider of the configuration flags explicitly set on
+// the command line. It can be added as the last provider, so the flags
+// take precedence over the other sources.
+type Flags struct {
+	fs       *flag.FlagSet
+	bindings []Binding
+}
+
+// Register registers a flag on fs for every leaf of the message, e.g.
+// `--server.http.addr` for `server.http.addr`. The flag usage is
+// the leading comment of the field, if the descriptor has the source info.
+//
+// The values are converted to the field types: lists are separated by commas
+// or set by repeating the flag, map entries are key=value pairs separated by
+// commas and durations use the time.ParseDuration format.
+func Register(fs *flag.FlagSet, desc protoreflect.MessageDescriptor, opts ...Option) *Flags {
+	flagOpts := options{
+		listSeparator: ",",
+	}
+
+	for _, opt := range opts {
+		opt(&flagOpts)
+	}
+
+	leaves := schema.Leaves(desc)
+	bindings := make([]Binding, 0, len(leaves))
+
+	for _, leaf := range leaves {
+		if !isFlagLeaf(leaf.Field()) {
+			continue
+		}
+
+		binding := Binding{
+			Name: flagOpts.name(leaf),
+			Path: leaf.Path(),
+			leaf: leaf,
+			value: &value{
+				fd:  leaf.Field(),
+				sep: flagOpts.listSeparator,
+				def: schema.Options(leaf.Field()).GetDefault(),
+			},
+		}
+
+		fs.Var(binding.value, binding.Name, usage(leaf))
+
+		bindings = append(bindings, binding)
+	}
+
+	return &Flags{
+		fs:       fs,
+		bindings: bindings,
+	}
+}
+
+// Bindings returns the bindings of the message fields.
+func (f *Flags) Bindings() []Binding {
+	bindings := make([]Binding, len(f.bindings))
+	copy(bindings, f.bindings)
+
+	return bindings
+}
+
+// Name returns the provider name.
+func (f *Flags) Name() string {
+	return "flags"
+}
+
+// ReadBytes returns the configuration encoded as JSON.
+func (f *Flags) ReadBytes() ([]byte, error) {
+	values, err := f.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("json marshal: %w", err)
+	}
+
+	return data, nil
+}
+
+// Read returns the values of the flags set on the command line.
+// The flag set must be parsed before.
+func (f *Flags) Read() (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+
+	for _, binding := range f.bindings {
+		if !binding.value.set {
+			continue
+		}
+
+		convert.Set(values, binding.leaf.Names(), binding.value.value)
+	}
+
+	return values, nil
+}
+
+func (o options) name(leaf schema.Leaf) string {
+	parts := leaf.Names()
+	for i, part := range parts {
+		parts[i] = strings.ReplaceAll(part, "_", "-")
+	}
+
+	if o.prefix != "" {
+		parts = append([]string{o.prefix}, parts...)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// isFlagLeaf reports whether the field value can be set by a flag.
+// Lists and maps of messages are not supported.
+func isFlagLeaf(fd protoreflect.FieldDescriptor) bool {
+	elem := fd
+	if fd.IsMap() {
+		elem = fd.MapValue()
+	}
+
+	if !fd.IsList() && !fd.IsMap() {
+		return true
+	}
+
+	return elem.Message() == nil || schema.IsWellKnownType(elem.Message())
+}
+
+func usage(leaf schema.Leaf) string {
+	comments := schema.Comments(leaf.Field())
+	if comments == "" {
+		return leaf.Path()
+	}
+
+	return comments
+}
@@ -0,0 +1,154 @@
+package flags
+
+import (
+	"flag"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/gosynergy/protoconf"
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+)
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	return fs
+}
+
+// descriptorWithComments returns the descriptor built with the source info.
+func descriptorWithComments(t *testing.T, name protoreflect.FullName) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	data, err := os.ReadFile("../conf/config.binpb")
+	require.NoError(t, err)
+
+	var set descriptorpb.FileDescriptorSet
+	require.NoError(t, proto.Unmarshal(data, &set))
+
+	files, err := protodesc.NewFiles(&set)
+	require.NoError(t, err)
+
+	desc, err := files.FindDescriptorByName(name)
+	require.NoError(t, err)
+
+	msgDesc, ok := desc.(protoreflect.MessageDescriptor)
+	require.True(t, ok)
+
+	return msgDesc
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	flags := Register(fs, descriptorWithComments(t, "conf.v1.Config"))
+
+	names := make(map[string]string)
+	for _, binding := range flags.Bindings() {
+		names[binding.Path] = binding.Name
+	}
+
+	assert.Equal(t, "server.http.addr", names["server.http.addr"])
+	assert.Equal(t, "data.redis.read-timeout", names["data.redis.read_timeout"])
+
+	addr := fs.Lookup("server.http.addr")
+	require.NotNil(t, addr)
+	assert.Equal(t, "HTTP listen address.", addr.Usage)
+
+	timeout := fs.Lookup("server.http.timeout")
+	require.NotNil(t, timeout)
+	assert.Equal(t, "1s", timeout.DefValue)
+}
+
+func TestFlags_Read(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	flags := Register(fs, (&v1.ConfigWithDefaults{}).ProtoReflect().Descriptor(), WithPrefix("config"))
+
+	err := fs.Parse([]string{
+		"--config.server.addr=:80",
+		"--config.server.timeout", "250ms",
+		"--config.server.hosts", "a,b",
+		"--config.server.hosts", "c",
+		"--config.log.json",
+	})
+	require.NoError(t, err)
+
+	values, err := flags.Read()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"server": map[string]interface{}{
+			"addr":    ":80",
+			"timeout": "0.250s",
+			"hosts":   []interface{}{"a", "b", "c"},
+		},
+		"log": map[string]interface{}{
+			"json": true,
+		},
+	}, values)
+	assert.Equal(t, "a,b,c", fs.Lookup("config.server.hosts").Value.String())
+}
+
+func TestFlags_RepeatedScalar(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	flags := Register(fs, (&v1.Config{}).ProtoReflect().Descriptor())
+	require.NoError(t, fs.Parse([]string{"--server.http.addr=a", "--server.http.addr=b"}))
+
+	values, err := flags.Read()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"server": map[string]interface{}{
+			"http": map[string]interface{}{
+				"addr": "b",
+			},
+		},
+	}, values)
+	assert.Equal(t, "b", fs.Lookup("server.http.addr").Value.String())
+}
+
+func TestFlags_InvalidValue(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	Register(fs, (&v1.ConfigWithDefaults{}).ProtoReflect().Descriptor())
+
+	err := fs.Parse([]string{"--server.max-conns=many"})
+	require.Error(t, err)
+}
+
+func TestFlags_Layer(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	flags := Register(fs, (&v1.Config{}).ProtoReflect().Descriptor())
+	require.NoError(t, fs.Parse([]string{"--server.http.addr=0.0.0.0:80"}))
+
+	loader, err := protoconf.New(
+		protoconf.WithLayer(file.Provider("../conf/config.yaml"), yaml.Parser()),
+		protoconf.WithProvider(flags),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.Config
+	require.NoError(t, loader.Scan(&cfg))
+
+	assert.Equal(t, "0.0.0.0:80", cfg.GetServer().GetHttp().GetAddr())
+	assert.Equal(t, "0.0.0.0:9000", cfg.GetServer().GetGrpc().GetAddr())
+}
@@ -0,0 +1,25 @@
+package flags
+
+// Option is flags option.
+type Option func(*options)
+
+type options struct {
+	prefix        string
+	listSeparator string
+}
+
+// WithPrefix sets the prefix of the flag names, e.g. `config` for
+// `--config.server.http.addr`.
+func WithPrefix(prefix string) Option {
+	return func(opts *options) {
+		opts.prefix = prefix
+	}
+}
+
+// WithListSeparator sets the separator of the list elements and
+// the map entries. The default is `,`.
+func WithListSeparator(sep string) Option {
+	return func(opts *options) {
+		opts.listSeparator = sep
+	}
+}
@@ -0,0 +1,77 @@
+package flags
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/gosynergy/protoconf/internal/convert"
+)
+
+// value is a flag.Value of a configuration field.
+type value struct {
+	fd  protoreflect.FieldDescriptor
+	sep string
+	def string
+
+	raw   string
+	value interface{}
+	set   bool
+}
+
+func (v *value) String() string {
+	if v == nil {
+		return ""
+	}
+
+	if !v.set {
+		return v.def
+	}
+
+	return v.raw
+}
+
+// Set converts the flag value. The values of a repeated list or map flag are
+// appended to the previous ones, a repeated scalar flag keeps the last value.
+func (v *value) Set(s string) error {
+	converted, err := convert.Value(v.fd, s, v.sep)
+	if err != nil {
+		return err
+	}
+
+	if v.set && (v.fd.IsList() || v.fd.IsMap()) {
+		converted = appendValue(v.value, converted)
+		s = v.raw + v.sep + s
+	}
+
+	v.raw = s
+	v.value = converted
+	v.set = true
+
+	return nil
+}
+
+// IsBoolFlag allows to set the bool flags without a value.
+func (v *value) IsBoolFlag() bool {
+	return v.fd.Kind() == protoreflect.BoolKind && !v.fd.IsList() && !v.fd.IsMap()
+}
+
+func appendValue(prev, next interface{}) interface{} {
+	switch nextValue := next.(type) {
+	case []interface{}:
+		prevList, _ := prev.([]interface{})
+
+		return append(prevList, nextValue...)
+	case map[string]interface{}:
+		prevMap, ok := prev.(map[string]interface{})
+		if !ok {
+			return nextValue
+		}
+
+		for key, v := range nextValue {
+			prevMap[key] = v
+		}
+
+		return prevMap
+	default:
+		return next
+	}
+}
@@ -0,0 +1,297 @@
+package protoconf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// IncludeKey is the key of the include directive, e.g.
+// `$include: ./common/redis.yaml`, see WithIncludes.
+const IncludeKey = "$include"
+
+var (
+	ErrIncludeCycle   = errors.New("include cycle")
+	ErrInvalidInclude = errors.New("invalid include")
+)
+
+// source is the values read from a provider or a file.
+type source struct {
+	values    map[string]interface{}
+	positions map[string]Position
+	// origins are the origins of the included values by their path,
+	// see WithIncludes.
+	origins map[string]Origin
+}
+
+// readSource reads the layer and resolves its includes.
+func (c *ConfigLoader) readSource(ctx context.Context, l layer) (*source, error) {
+	values, positions, err := c.read(ctx, l)
+	if err != nil {
+		return nil, err
+	}
+
+	src := &source{
+		values:    values,
+		positions: positions,
+	}
+
+	if !c.opts.includes {
+		return src, nil
+	}
+
+	var (
+		file  string
+		stack []string
+	)
+
+	fileProvider, ok := l.provider.(FileProvider)
+	if ok {
+		file = fileProvider.Path()
+		stack = []string{absPath(file)}
+	}
+
+	r := &includer{
+		loader: c,
+		ctx:    ctx,
+		parser: l.parser,
+		ext:    strings.ToLower(filepath.Ext(file)),
+	}
+
+	src.values, src.origins, err = r.resolveMap(values, filepath.Dir(file), stack)
+	if err != nil {
+		return nil, err
+	}
+
+	return src, nil
+}
+
+// includer resolves the include directives of a layer.
+type includer struct {
+	loader *ConfigLoader
+	ctx    context.Context //nolint:containedctx
+	// parser is the parser of the layer.
+	parser Parser
+	// ext is the extension of the layer file.
+	ext string
+}
+
+// resolveMap replaces the include directive of the values with the included
+// values, merged under the other keys of the values. The included values
+// are resolved relative to dir, the directory of the including file. stack
+// is the chain of the including files, to detect the cycles.
+func (r *includer) resolveMap(
+	values map[string]interface{},
+	dir string,
+	stack []string,
+) (map[string]interface{}, map[string]Origin, error) {
+	resolved := make(map[string]interface{}, len(values))
+	origins := make(map[string]Origin)
+
+	for key, value := range values {
+		if key == IncludeKey {
+			continue
+		}
+
+		resolvedValue, valueOrigins, err := r.resolveValue(value, dir, stack)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		resolved[key] = resolvedValue
+
+		for path, origin := range valueOrigins {
+			origins[joinPath(key, path)] = origin
+		}
+	}
+
+	ref, ok := values[IncludeKey]
+	if !ok {
+		return resolved, origins, nil
+	}
+
+	refs, err := includeRefs(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	included := make(map[string]interface{})
+
+	for _, ref := range refs {
+		includedValues, includedOrigins, err := r.include(ref, dir, stack)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		included = overlay(included, includedValues)
+
+		// the values of the including file take precedence
+		leaves := flatten(resolved)
+
+		for path, origin := range includedOrigins {
+			_, ok := leaves[path]
+			if !ok {
+				origins[path] = origin
+			}
+		}
+	}
+
+	return overlay(included, resolved), origins, nil
+}
+
+func (r *includer) resolveValue(
+	value interface{},
+	dir string,
+	stack []string,
+) (interface{}, map[string]Origin, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return r.resolveMap(v, dir, stack)
+	case []interface{}:
+		// the origin of a list element is the origin of the list
+		resolved := make([]interface{}, len(v))
+
+		for i, elem := range v {
+			resolvedElem, _, err := r.resolveValue(elem, dir, stack)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			resolved[i] = resolvedElem
+		}
+
+		return resolved, nil, nil
+	default:
+		return v, nil, nil
+	}
+}
+
+// include reads and resolves the included file.
+func (r *includer) include(ref, dir string, stack []string) (map[string]interface{}, map[string]Origin, error) {
+	path := ref
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	abs := absPath(path)
+
+	for i, including := range stack {
+		if including == abs {
+			chain := append(append([]string(nil), stack[i:]...), abs)
+
+			return nil, nil, fmt.Errorf("%w: %s", ErrIncludeCycle, strings.Join(chain, " -> "))
+		}
+	}
+
+	provider := &localFile{path: path}
+	parser := r.parserOf(path)
+
+	values, positions, err := r.loader.read(r.ctx, layer{provider: provider, parser: parser})
+	if err != nil {
+		return nil, nil, fmt.Errorf("include %s: %w", ref, err)
+	}
+
+	resolved, nestedOrigins, err := r.resolveMap(values, filepath.Dir(path), append(stack[:len(stack):len(stack)], abs))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	origins := make(map[string]Origin)
+
+	for leaf := range flatten(resolved) {
+		origin := providerOrigin(provider)
+		origin.Position = positions[leaf]
+		origins[leaf] = origin
+	}
+
+	for path, origin := range nestedOrigins {
+		origins[path] = origin
+	}
+
+	return resolved, origins, nil
+}
+
+// parserOf returns the parser of the included file: the parser set by
+// WithIncludeParser for its extension, the parser of the layer if the file
+// has the extension of the layer file, or the parser of the registry for
+// its extension. The included file is parsed like the layer otherwise.
+func (r *includer) parserOf(path string) Parser {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	parser, ok := r.loader.opts.includeParsers[ext]
+	if ok {
+		return parser
+	}
+
+	if ext == r.ext && r.parser != nil {
+		return r.parser
+	}
+
+	if r.loader.opts.registry != nil {
+		parser, ok = r.loader.opts.registry.ByExtension(path)
+		if ok {
+			return parser
+		}
+	}
+
+	return r.parser
+}
+
+// includeRefs returns the paths of the include directive, a path or a list
+// of paths.
+func includeRefs(ref interface{}) ([]string, error) {
+	switch v := ref.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		refs := make([]string, 0, len(v))
+
+		for _, elem := range v {
+			s, ok := elem.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %v is not a path", ErrInvalidInclude, elem)
+			}
+
+			refs = append(refs, s)
+		}
+
+		return refs, nil
+	default:
+		return nil, fmt.Errorf("%w: %v is not a path", ErrInvalidInclude, ref)
+	}
+}
+
+// overlay returns dst with the values of src, merging the nested maps.
+func overlay(dst, src map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(dst)+len(src))
+
+	for key, value := range dst {
+		merged[key] = value
+	}
+
+	for key, value := range src {
+		srcMap, srcOK := value.(map[string]interface{})
+		dstMap, dstOK := merged[key].(map[string]interface{})
+
+		if srcOK && dstOK {
+			merged[key] = overlay(dstMap, srcMap)
+
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+
+	return abs
+}
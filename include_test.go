@@ -0,0 +1,88 @@
+package protoconf
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/parsers/json"
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+	"github.com/gosynergy/protoconf/provider/file"
+)
+
+func TestConfigLoader_Includes(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithLayer(file.Provider("conf/include/config.yaml"), linePositionParser{yaml.Parser()}),
+		WithIncludes(),
+		WithIncludeParser(".JSON", json.Parser()),
+		WithStrict(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.Config
+	require.NoError(t, loader.Scan(&cfg))
+
+	assert.Equal(t, "mysql", cfg.GetData().GetDatabase().GetDriver())
+	assert.Equal(t, "tcp", cfg.GetData().GetRedis().GetNetwork())
+	assert.Equal(t, "127.0.0.1:6380", cfg.GetData().GetRedis().GetAddr())
+	assert.Equal(t, 200*time.Millisecond, cfg.GetData().GetRedis().GetReadTimeout().AsDuration())
+
+	tests := []struct {
+		path   string
+		origin string
+	}{
+		{path: "data.redis.addr", origin: "conf/include/config.yaml:8:5 (file)"},
+		{path: "data.redis.network", origin: "conf/include/common/redis.yaml:2:1 (file)"},
+		{path: "data.redis.read_timeout", origin: "conf/include/common/timeouts.yaml:1:1 (file)"},
+		{path: "data.database.driver", origin: "conf/include/common/database.json (file)"},
+	}
+
+	for _, test := range tests {
+		origin, ok := loader.Explain(test.path)
+		require.True(t, ok, test.path)
+		assert.Equal(t, test.origin, origin.String(), test.path)
+	}
+}
+
+func TestConfigLoader_Includes_Cycle(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithLayer(file.Provider("conf/include/cycle-a.yaml"), yaml.Parser()),
+		WithIncludes(),
+	)
+	require.NoError(t, err)
+
+	err = loader.Load()
+	require.ErrorIs(t, err, ErrIncludeCycle)
+	assert.Contains(t, err.Error(), filepath.Join("conf", "include", "cycle-b.yaml"))
+}
+
+func TestIncludeRefs_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := includeRefs([]interface{}{"a.yaml", 1})
+	require.ErrorIs(t, err, ErrInvalidInclude)
+
+	_, err = includeRefs(map[string]interface{}{})
+	require.ErrorIs(t, err, ErrInvalidInclude)
+}
+
+func TestConfigLoader_Includes_Disabled(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(WithLayer(file.Provider("conf/include/config.yaml"), yaml.Parser()))
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.Config
+	require.NoError(t, loader.Scan(&cfg))
+	assert.Nil(t, cfg.GetData().GetDatabase())
+}
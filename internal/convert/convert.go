@@ -0,0 +1,173 @@
+// Package convert converts strings, e.g. environment variables or command-line
+// flags, into configuration values of a field type.
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/gosynergy/protoconf/internal/schema"
+)
+
+var ErrInvalidMapEntry = errors.New("invalid map entry, expected key=value")
+
+// Value converts the string into the JSON compatible value of the field.
+//
+// Lists are either JSON arrays or values separated by sep, maps are either
+// JSON objects or key=value pairs separated by sep. Durations accept
+// the time.ParseDuration format, messages are JSON objects.
+func Value(fd protoreflect.FieldDescriptor, s string, sep string) (interface{}, error) {
+	switch {
+	case fd.IsMap():
+		return mapValue(fd, s, sep)
+	case fd.IsList():
+		return listValue(fd, s, sep)
+	default:
+		return Singular(fd, s)
+	}
+}
+
+// Set sets the value at the keys of the nested values, creating the missing
+// maps, e.g. `{server: {http: {addr: value}}}` for `server`, `http`, `addr`.
+func Set(values map[string]interface{}, keys []string, value interface{}) {
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := values[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			values[key] = next
+		}
+
+		values = next
+	}
+
+	values[keys[len(keys)-1]] = value
+}
+
+// Singular converts the string into the JSON compatible value of a single
+// element of the field.
+func Singular(fd protoreflect.FieldDescriptor, s string) (interface{}, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return strconv.ParseBool(s)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.ParseInt(s, 10, 64)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.ParseUint(s, 10, 64)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return strconv.ParseFloat(s, 64)
+	case protoreflect.EnumKind:
+		number, err := strconv.ParseInt(s, 10, 32)
+		if err == nil {
+			return number, nil
+		}
+
+		return s, nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageValue(fd.Message(), s)
+	case protoreflect.StringKind, protoreflect.BytesKind:
+	}
+
+	return s, nil
+}
+
+func messageValue(desc protoreflect.MessageDescriptor, s string) (interface{}, error) {
+	if !schema.IsWellKnownType(desc) {
+		return jsonValue(s)
+	}
+
+	switch desc.FullName() {
+	case "google.protobuf.Duration":
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("parse duration: %w", err)
+		}
+
+		data, err := protojson.Marshal(durationpb.New(d))
+		if err != nil {
+			return nil, fmt.Errorf("marshal duration: %w", err)
+		}
+
+		return jsonValue(string(data))
+	case "google.protobuf.Struct", "google.protobuf.ListValue", "google.protobuf.Value", "google.protobuf.Any":
+		return jsonValue(s)
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		return Singular(desc.Fields().ByName("value"), s)
+	}
+
+	// Timestamp, FieldMask and Empty are represented as strings
+	return s, nil
+}
+
+func listValue(fd protoreflect.FieldDescriptor, s string, sep string) (interface{}, error) {
+	if strings.HasPrefix(strings.TrimSpace(s), "[") {
+		return jsonValue(s)
+	}
+
+	values := make([]interface{}, 0)
+
+	if s == "" {
+		return values, nil
+	}
+
+	for _, elem := range strings.Split(s, sep) {
+		value, err := Singular(fd, strings.TrimSpace(elem))
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func mapValue(fd protoreflect.FieldDescriptor, s string, sep string) (interface{}, error) {
+	if strings.HasPrefix(strings.TrimSpace(s), "{") {
+		return jsonValue(s)
+	}
+
+	values := make(map[string]interface{})
+
+	if s == "" {
+		return values, nil
+	}
+
+	for _, entry := range strings.Split(s, sep) {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q: %w", entry, ErrInvalidMapEntry)
+		}
+
+		converted, err := Singular(fd.MapValue(), strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+
+		values[strings.TrimSpace(key)] = converted
+	}
+
+	return values, nil
+}
+
+func jsonValue(s string) (interface{}, error) {
+	var value interface{}
+
+	err := json.Unmarshal([]byte(s), &value)
+	if err != nil {
+		return nil, fmt.Errorf("json unmarshal: %w", err)
+	}
+
+	return value, nil
+}
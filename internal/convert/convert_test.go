@@ -0,0 +1,88 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+)
+
+func field(m interface {
+	ProtoReflect() protoreflect.Message
+}, name string,
+) protoreflect.FieldDescriptor {
+	return m.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(name))
+}
+
+func TestValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		fd       protoreflect.FieldDescriptor
+		value    string
+		expected interface{}
+	}{
+		{"string", field(&v1.ConfigWithDefaults_Server{}, "addr"), ":80", ":80"},
+		{"uint", field(&v1.ConfigWithDefaults_Server{}, "max_conns"), "3", uint64(3)},
+		{"duration", field(&v1.ConfigWithDefaults_Server{}, "timeout"), "1h1s", "3601s"},
+		{"list", field(&v1.ConfigWithDefaults_Server{}, "hosts"), "a,b", []interface{}{"a", "b"}},
+		{"json list", field(&v1.ConfigWithDefaults_Server{}, "hosts"), `["a,b"]`, []interface{}{"a,b"}},
+		{"empty list", field(&v1.ConfigWithDefaults_Server{}, "hosts"), "", []interface{}{}},
+		{"enum name", field(&v1.ConfigWithDefaults_Log{}, "level"), "LEVEL_INFO", "LEVEL_INFO"},
+		{"enum number", field(&v1.ConfigWithDefaults_Log{}, "level"), "2", int64(2)},
+		{"bool", field(&v1.ConfigWithDefaults_Log{}, "json"), "1", true},
+		{"double", field(&v1.ConfigWithDefaults{}, "ratio"), "1e-3", 0.001},
+		{"map", field(&v1.ConfigWithMerge{}, "labels"), "a=1,b=2", map[string]interface{}{"a": "1", "b": "2"}},
+		{"message", field(&v1.ConfigWithMerge_Listener{}, "labels"), `{"a": "1"}`, map[string]interface{}{"a": "1"}},
+		{"wrapper", field(&wrapperspb.Int32Value{}, "value"), "7", int64(7)},
+		{"struct", field(&structpb.Value{}, "struct_value"), `{"a": true}`, map[string]interface{}{"a": true}},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			value, err := Value(test.fd, test.value, ",")
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
+
+func TestValue_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := Value(field(&v1.ConfigWithMerge{}, "labels"), "a", ",")
+	require.ErrorIs(t, err, ErrInvalidMapEntry)
+
+	_, err = Value(field(&v1.ConfigWithDefaults_Server{}, "timeout"), "soon", ",")
+	require.Error(t, err)
+}
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]interface{}{
+		"server": map[string]interface{}{"grpc": map[string]interface{}{"addr": ":9000"}},
+		"name":   "app",
+	}
+
+	Set(values, []string{"server", "http", "addr"}, ":80")
+	Set(values, []string{"name", "first"}, "a")
+
+	assert.Equal(t, map[string]interface{}{
+		"server": map[string]interface{}{
+			"grpc": map[string]interface{}{"addr": ":9000"},
+			"http": map[string]interface{}{"addr": ":80"},
+		},
+		"name": map[string]interface{}{"first": "a"},
+	}, values)
+}
@@ -0,0 +1,135 @@
+// Package schema contains helpers to walk configuration message descriptors.
+package schema
+
+import (
+	"strings"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	protoconfpb "github.com/gosynergy/protoconf/protoconf"
+)
+
+// Leaf is a configuration value which is not split into nested keys.
+type Leaf struct {
+	// Fields is the path from the root message to the leaf field.
+	Fields []protoreflect.FieldDescriptor
+}
+
+// Field returns the leaf field.
+func (l Leaf) Field() protoreflect.FieldDescriptor {
+	return l.Fields[len(l.Fields)-1]
+}
+
+// Names returns the proto names of the path fields.
+func (l Leaf) Names() []string {
+	names := make([]string, len(l.Fields))
+	for i, fd := range l.Fields {
+		names[i] = string(fd.Name())
+	}
+
+	return names
+}
+
+// Path returns the dotted path of the leaf, e.g. `server.http.addr`.
+func (l Leaf) Path() string {
+	return strings.Join(l.Names(), ".")
+}
+
+// Leaves returns the leaves of the message in the declaration order. Singular
+// messages are split into their fields, while scalars, enums, well-known
+// types, lists, maps and recursive messages are leaves.
+func Leaves(desc protoreflect.MessageDescriptor) []Leaf {
+	return leaves(desc, nil, map[protoreflect.FullName]bool{desc.FullName(): true})
+}
+
+func leaves(
+	desc protoreflect.MessageDescriptor,
+	path []protoreflect.FieldDescriptor,
+	visiting map[protoreflect.FullName]bool,
+) []Leaf {
+	var result []Leaf
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		fieldPath := make([]protoreflect.FieldDescriptor, len(path), len(path)+1)
+		copy(fieldPath, path)
+		fieldPath = append(fieldPath, fd)
+
+		msgDesc := fd.Message()
+		if fd.IsList() || fd.IsMap() || msgDesc == nil || IsWellKnownType(msgDesc) || visiting[msgDesc.FullName()] {
+			result = append(result, Leaf{Fields: fieldPath})
+
+			continue
+		}
+
+		visiting[msgDesc.FullName()] = true
+		result = append(result, leaves(msgDesc, fieldPath, visiting)...)
+		delete(visiting, msgDesc.FullName())
+	}
+
+	return result
+}
+
+// IsWellKnownType reports whether the message has a special JSON mapping.
+func IsWellKnownType(desc protoreflect.MessageDescriptor) bool {
+	return desc.ParentFile().Package() == "google.protobuf"
+}
+
+// FieldByKey returns the field of the message for a configuration key, which
+// is either the proto or the JSON name of the field, like protojson does.
+func FieldByKey(desc protoreflect.MessageDescriptor, key string) protoreflect.FieldDescriptor {
+	fields := desc.Fields()
+
+	fd := fields.ByName(protoreflect.Name(key))
+	if fd != nil {
+		return fd
+	}
+
+	return fields.ByJSONName(key)
+}
+
+// Options returns the `(protoconf.field)` options of the field.
+func Options(fd protoreflect.FieldDescriptor) *protoconfpb.FieldOptions {
+	fieldOpts, _ := proto.GetExtension(fd.Options(), protoconfpb.E_Field).(*protoconfpb.FieldOptions)
+
+	return fieldOpts
+}
+
+// Comments returns the leading comments of the descriptor joined into
+// a single line. Descriptors without source info have no comments.
+func Comments(desc protoreflect.Descriptor) string {
+	comments := desc.ParentFile().SourceLocations().ByDescriptor(desc).LeadingComments
+
+	return strings.Join(strings.Fields(comments), " ")
+}
+
+// Constraints returns the `(buf.validate.field)` constraints of the field.
+func Constraints(fd protoreflect.FieldDescriptor) *validate.FieldConstraints {
+	constraints, _ := proto.GetExtension(fd.Options(), validate.E_Field).(*validate.FieldConstraints)
+
+	return constraints
+}
+
+// Deprecated reports whether the field is marked with the `deprecated` option.
+func Deprecated(fd protoreflect.FieldDescriptor) bool {
+	fieldOpts, ok := fd.Options().(*descriptorpb.FieldOptions)
+
+	return ok && fieldOpts.GetDeprecated()
+}
+
+// ConfigOptions returns the `(protoconf.config)` options of the message,
+// or nil if the message is not marked as a configuration message.
+func ConfigOptions(desc protoreflect.MessageDescriptor) *protoconfpb.ConfigOptions {
+	if !proto.HasExtension(desc.Options(), protoconfpb.E_Config) {
+		return nil
+	}
+
+	configOpts, _ := proto.GetExtension(desc.Options(), protoconfpb.E_Config).(*protoconfpb.ConfigOptions)
+
+	return configOpts
+}
@@ -0,0 +1,213 @@
+package jsonschema
+
+import (
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// stringFormats are the JSON Schema formats of the well-known string rules.
+var stringFormats = map[protoreflect.Name]string{
+	"email":    "email",
+	"hostname": "hostname",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"uri":      "uri",
+	"uri_ref":  "uri-reference",
+	"uuid":     "uuid",
+}
+
+// applyConstraints translates the protovalidate constraints of the field
+// into schema keywords. The constraints without an equivalent keyword,
+// e.g. CEL expressions, are ignored.
+func applyConstraints(s *Schema, fd protoreflect.FieldDescriptor, constraints *validate.FieldConstraints) {
+	switch {
+	case constraints == nil:
+	case constraints.GetRepeated() != nil:
+		rules := constraints.GetRepeated()
+		s.MinItems = uintValue(rules.MinItems)
+		s.MaxItems = uintValue(rules.MaxItems)
+		s.UniqueItems = rules.GetUnique()
+
+		if s.Items != nil {
+			applyConstraints(s.Items, fd, rules.GetItems())
+		}
+	case constraints.GetMap() != nil:
+		rules := constraints.GetMap()
+		s.MinProperties = uintValue(rules.MinPairs)
+		s.MaxProperties = uintValue(rules.MaxPairs)
+
+		if rules.GetKeys() != nil {
+			if s.PropertyNames == nil {
+				s.PropertyNames = &Schema{}
+			}
+
+			applyConstraints(s.PropertyNames, fd.MapKey(), rules.GetKeys())
+		}
+
+		values, ok := s.AdditionalProperties.(*Schema)
+		if ok {
+			applyConstraints(values, fd.MapValue(), rules.GetValues())
+		}
+	case constraints.GetString_() != nil:
+		applyStringRules(s, constraints.GetString_())
+	case constraints.GetEnum() != nil:
+		applyEnumRules(s, fd.Enum(), constraints.GetEnum())
+	default:
+		rules := constraints.ProtoReflect()
+
+		oneof := rules.Descriptor().Oneofs().ByName("type")
+		if oneof == nil {
+			return
+		}
+
+		// The duration and timestamp rules are not comparable in the schema.
+		typeField := rules.WhichOneof(oneof)
+		if typeField != nil && !isMessageRules(typeField.Message()) {
+			applyNumberRules(s, rules.Get(typeField).Message())
+		}
+	}
+}
+
+func applyStringRules(s *Schema, rules *validate.StringRules) {
+	if rules.Const != nil {
+		s.Const = rules.GetConst()
+	}
+
+	if rules.Len != nil {
+		s.MinLength = uintValue(rules.Len)
+		s.MaxLength = uintValue(rules.Len)
+	}
+
+	if rules.MinLen != nil {
+		s.MinLength = uintValue(rules.MinLen)
+	}
+
+	if rules.MaxLen != nil {
+		s.MaxLength = uintValue(rules.MaxLen)
+	}
+
+	s.Pattern = rules.GetPattern()
+
+	for _, value := range rules.GetIn() {
+		s.Enum = append(s.Enum, value)
+	}
+
+	if len(rules.GetNotIn()) > 0 {
+		notIn := make([]interface{}, 0, len(rules.GetNotIn()))
+		for _, value := range rules.GetNotIn() {
+			notIn = append(notIn, value)
+		}
+
+		s.Not = &Schema{Enum: notIn}
+	}
+
+	reflectRules := rules.ProtoReflect()
+
+	wellKnown := reflectRules.WhichOneof(reflectRules.Descriptor().Oneofs().ByName("well_known"))
+	if wellKnown != nil && reflectRules.Get(wellKnown).Interface() == true {
+		s.Format = stringFormats[wellKnown.Name()]
+	}
+}
+
+// applyEnumRules restricts the enum values to the allowed ones.
+func applyEnumRules(s *Schema, desc protoreflect.EnumDescriptor, rules *validate.EnumRules) {
+	allowed := func(number int32) bool {
+		if rules.Const != nil {
+			return number == rules.GetConst()
+		}
+
+		if len(rules.GetIn()) > 0 && !containsNumber(rules.GetIn(), number) {
+			return false
+		}
+
+		return !containsNumber(rules.GetNotIn(), number)
+	}
+
+	var names, numbers []interface{}
+
+	values := desc.Values()
+	for i := 0; i < values.Len(); i++ {
+		value := values.Get(i)
+		if allowed(int32(value.Number())) {
+			names = append(names, string(value.Name()))
+			numbers = append(numbers, int64(value.Number()))
+		}
+	}
+
+	s.Enum = append(names, numbers...)
+}
+
+// applyNumberRules translates the rules of any numeric type, which all
+// have the same const, lt, lte, gt, gte and in fields.
+func applyNumberRules(s *Schema, rules protoreflect.Message) {
+	rules.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch fd.Name() {
+		case "const":
+			s.Const = value.Interface()
+		case "lt":
+			s.ExclusiveMaximum = floatValue(value)
+		case "lte":
+			s.Maximum = floatValue(value)
+		case "gt":
+			s.ExclusiveMinimum = floatValue(value)
+		case "gte":
+			s.Minimum = floatValue(value)
+		case "in":
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				s.Enum = append(s.Enum, list.Get(i).Interface())
+			}
+		}
+
+		return true
+	})
+}
+
+func isMessageRules(desc protoreflect.MessageDescriptor) bool {
+	constField := desc.Fields().ByName("const")
+
+	return constField == nil || constField.Kind() == protoreflect.MessageKind
+}
+
+func containsNumber(numbers []int32, number int32) bool {
+	for _, n := range numbers {
+		if n == number {
+			return true
+		}
+	}
+
+	return false
+}
+
+func uintValue(v *uint64) *uint64 {
+	if v == nil {
+		return nil
+	}
+
+	value := *v
+
+	return &value
+}
+
+func floatValue(value protoreflect.Value) *float64 {
+	var f float64
+
+	switch v := value.Interface().(type) {
+	case int32:
+		f = float64(v)
+	case int64:
+		f = float64(v)
+	case uint32:
+		f = float64(v)
+	case uint64:
+		f = float64(v)
+	case float32:
+		f = float64(v)
+	case float64:
+		f = v
+	default:
+		return nil
+	}
+
+	return &f
+}
@@ -0,0 +1,286 @@
+package jsonschema
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/gosynergy/protoconf/internal/schema"
+)
+
+const (
+	durationPattern = `^-?[0-9]+(\.[0-9]{1,9})?s$`
+	integerPattern  = `^-?[0-9]+$`
+	unsignedPattern = `^[0-9]+$`
+)
+
+type generator struct {
+	opts options
+	root protoreflect.MessageDescriptor
+	defs map[string]*Schema
+}
+
+// Generate returns the JSON Schema of the message. The nested messages are
+// defined in `$defs` by their full name. The field comments are used as
+// descriptions when the descriptor has source info, e.g. when it is read
+// from a descriptor set built by `buf build -o`.
+func Generate(desc protoreflect.MessageDescriptor, opts ...Option) *Schema {
+	g := &generator{
+		root: desc,
+		defs: make(map[string]*Schema),
+	}
+
+	for _, opt := range opts {
+		opt(&g.opts)
+	}
+
+	root := g.message(desc)
+	root.Schema = Draft
+	root.Title = string(desc.FullName())
+
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+
+	return root
+}
+
+// message returns the schema of a message which is not a well-known type.
+func (g *generator) message(desc protoreflect.MessageDescriptor) *Schema {
+	s := &Schema{
+		Type:        "object",
+		Description: schema.Comments(desc),
+		Properties:  make(map[string]*Schema),
+	}
+
+	if g.opts.strict {
+		s.AdditionalProperties = false
+	}
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := g.name(fd)
+
+		s.Properties[name] = g.field(fd)
+
+		if schema.Constraints(fd).GetRequired() {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	oneofs := desc.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		oneof := oneofs.Get(i)
+		if !oneof.IsSynthetic() {
+			s.AllOf = append(s.AllOf, g.oneof(oneof))
+		}
+	}
+
+	return s
+}
+
+// oneof returns the schema allowing at most one field of the oneof.
+func (g *generator) oneof(oneof protoreflect.OneofDescriptor) *Schema {
+	fields := oneof.Fields()
+	branches := make([]*Schema, 0, fields.Len()+1)
+
+	for i := 0; i < fields.Len(); i++ {
+		branches = append(branches, &Schema{Required: []string{g.name(fields.Get(i))}})
+	}
+
+	none := &Schema{Not: &Schema{AnyOf: branches}}
+
+	return &Schema{OneOf: append(branches, none)}
+}
+
+func (g *generator) name(fd protoreflect.FieldDescriptor) string {
+	if g.opts.jsonNames {
+		return fd.JSONName()
+	}
+
+	return string(fd.Name())
+}
+
+// field returns the schema of the field with its description, default
+// and constraints.
+func (g *generator) field(fd protoreflect.FieldDescriptor) *Schema {
+	var s *Schema
+
+	switch {
+	case fd.IsMap():
+		s = &Schema{
+			Type:                 "object",
+			PropertyNames:        mapKey(fd.MapKey()),
+			AdditionalProperties: g.singular(fd.MapValue()),
+		}
+	case fd.IsList():
+		s = &Schema{
+			Type:  "array",
+			Items: g.singular(fd),
+		}
+	default:
+		s = g.singular(fd)
+	}
+
+	comments := schema.Comments(fd)
+	if comments != "" {
+		s.Description = comments
+	}
+
+	s.Deprecated = schema.Deprecated(fd)
+	s.Default = defaultValue(fd)
+
+	applyConstraints(s, fd, schema.Constraints(fd))
+
+	return s
+}
+
+// singular returns the schema of a single value of the field.
+func (g *generator) singular(fd protoreflect.FieldDescriptor) *Schema {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return &Schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &Schema{Type: "integer"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return &Schema{Type: []string{"integer", "string"}, Pattern: integerPattern}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &Schema{Type: []string{"integer", "string"}, Pattern: unsignedPattern}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return &Schema{Type: "number"}
+	case protoreflect.StringKind:
+		return &Schema{Type: "string"}
+	case protoreflect.BytesKind:
+		return &Schema{Type: "string", ContentEncoding: "base64"}
+	case protoreflect.EnumKind:
+		return enum(fd.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if schema.IsWellKnownType(fd.Message()) {
+			return wellKnownType(fd.Message())
+		}
+
+		return g.ref(fd.Message())
+	}
+
+	return &Schema{}
+}
+
+// ref returns the reference to the schema of the message in `$defs`.
+func (g *generator) ref(desc protoreflect.MessageDescriptor) *Schema {
+	if desc.FullName() == g.root.FullName() {
+		return &Schema{Ref: "#"}
+	}
+
+	name := string(desc.FullName())
+
+	_, ok := g.defs[name]
+	if !ok {
+		// Registered before the fields for the recursive messages.
+		g.defs[name] = nil
+		g.defs[name] = g.message(desc)
+	}
+
+	return &Schema{Ref: "#/$defs/" + name}
+}
+
+func enum(desc protoreflect.EnumDescriptor) *Schema {
+	if desc.FullName() == "google.protobuf.NullValue" {
+		return &Schema{Type: "null"}
+	}
+
+	values := desc.Values()
+	enum := make([]interface{}, 0, 2*values.Len())
+
+	for i := 0; i < values.Len(); i++ {
+		enum = append(enum, string(values.Get(i).Name()))
+	}
+
+	for i := 0; i < values.Len(); i++ {
+		enum = append(enum, int64(values.Get(i).Number()))
+	}
+
+	return &Schema{
+		Description: schema.Comments(desc),
+		Enum:        enum,
+	}
+}
+
+func mapKey(fd protoreflect.FieldDescriptor) *Schema {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return nil
+	case protoreflect.BoolKind:
+		return &Schema{Enum: []interface{}{"true", "false"}}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &Schema{Pattern: unsignedPattern}
+	default:
+		return &Schema{Pattern: integerPattern}
+	}
+}
+
+// wellKnownType returns the schema of the protojson mapping of
+// the well-known type.
+func wellKnownType(desc protoreflect.MessageDescriptor) *Schema {
+	switch desc.FullName() {
+	case "google.protobuf.Duration":
+		return &Schema{Type: "string", Pattern: durationPattern}
+	case "google.protobuf.Timestamp":
+		return &Schema{Type: "string", Format: "date-time"}
+	case "google.protobuf.FieldMask":
+		return &Schema{Type: "string"}
+	case "google.protobuf.Struct":
+		return &Schema{Type: "object"}
+	case "google.protobuf.ListValue":
+		return &Schema{Type: "array"}
+	case "google.protobuf.Value":
+		return &Schema{}
+	case "google.protobuf.Empty":
+		return &Schema{Type: "object", AdditionalProperties: false}
+	case "google.protobuf.Any":
+		return &Schema{
+			Type:       "object",
+			Properties: map[string]*Schema{"@type": {Type: "string"}},
+			Required:   []string{"@type"},
+		}
+	case "google.protobuf.BoolValue":
+		return &Schema{Type: "boolean"}
+	case "google.protobuf.Int32Value", "google.protobuf.UInt32Value":
+		return &Schema{Type: "integer"}
+	case "google.protobuf.Int64Value":
+		return &Schema{Type: []string{"integer", "string"}, Pattern: integerPattern}
+	case "google.protobuf.UInt64Value":
+		return &Schema{Type: []string{"integer", "string"}, Pattern: unsignedPattern}
+	case "google.protobuf.FloatValue", "google.protobuf.DoubleValue":
+		return &Schema{Type: "number"}
+	case "google.protobuf.StringValue":
+		return &Schema{Type: "string"}
+	case "google.protobuf.BytesValue":
+		return &Schema{Type: "string", ContentEncoding: "base64"}
+	}
+
+	return &Schema{}
+}
+
+// defaultValue returns the `(protoconf.field).default` option of the field
+// decoded from JSON. String fields and invalid JSON defaults are strings.
+func defaultValue(fd protoreflect.FieldDescriptor) interface{} {
+	def := schema.Options(fd).GetDefault()
+	if def == "" {
+		return nil
+	}
+
+	if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+		return def
+	}
+
+	var value interface{}
+
+	err := json.Unmarshal([]byte(def), &value)
+	if err != nil {
+		return def
+	}
+
+	return value
+}
@@ -0,0 +1,114 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	s := Generate((&v1.ConfigWithConstraints{}).ProtoReflect().Descriptor())
+
+	assert.Equal(t, Draft, s.Schema)
+	assert.Equal(t, "conf.v1.ConfigWithConstraints", s.Title)
+	assert.Equal(t, []string{"name"}, s.Required)
+	assert.Nil(t, s.AdditionalProperties)
+
+	tests := map[string]string{
+		"name":        `{"type": "string", "pattern": "^[a-z-]+$", "minLength": 3, "maxLength": 32}`,
+		"port":        `{"type": "integer", "minimum": 1, "maximum": 65535}`,
+		"mode":        `{"enum": ["MODE_DEV", "MODE_PROD", 1, 2]}`,
+		"region":      `{"type": "string", "enum": ["eu", "us"]}`,
+		"admin_email": `{"type": "string", "format": "email"}`,
+		"hosts": `{
+			"type": "array",
+			"items": {"type": "string", "format": "hostname"},
+			"minItems": 1,
+			"uniqueItems": true
+		}`,
+		"limits": `{
+			"type": "object",
+			"additionalProperties": {"type": ["integer", "string"], "pattern": "^-?[0-9]+$"},
+			"maxProperties": 8
+		}`,
+		"timeout":    `{"type": "string", "pattern": "^-?[0-9]+(\\.[0-9]{1,9})?s$"}`,
+		"not_before": `{"type": "string", "format": "date-time"}`,
+		"retries":    `{"type": "integer", "maximum": 10}`,
+		"legacy":     `{"type": "string", "deprecated": true}`,
+	}
+
+	for name, expected := range tests {
+		name, expected := name, expected
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := json.Marshal(s.Properties[name])
+			require.NoError(t, err)
+			assert.JSONEq(t, expected, string(data))
+		})
+	}
+}
+
+func TestGenerate_Oneof(t *testing.T) {
+	t.Parallel()
+
+	s := Generate((&v1.ConfigWithConstraints{}).ProtoReflect().Descriptor())
+
+	data, err := json.Marshal(s.AllOf)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"oneOf": [
+		{"required": ["file"]},
+		{"required": ["url"]},
+		{"not": {"anyOf": [{"required": ["file"]}, {"required": ["url"]}]}}
+	]}]`, string(data))
+}
+
+func TestGenerate_Defs(t *testing.T) {
+	t.Parallel()
+
+	s := Generate((&v1.ConfigWithDefaults{}).ProtoReflect().Descriptor(), WithJSONNames(), WithStrict())
+
+	assert.Equal(t, false, s.AdditionalProperties)
+	assert.Equal(t, "#/$defs/conf.v1.ConfigWithDefaults.Server", s.Properties["server"].Ref)
+	assert.Equal(t, map[string]interface{}{"addr": ":9090"}, s.Properties["server"].Default)
+
+	server := s.Defs["conf.v1.ConfigWithDefaults.Server"]
+	require.NotNil(t, server)
+	assert.Equal(t, false, server.AdditionalProperties)
+	assert.Equal(t, float64(100), server.Properties["maxConns"].Default)
+	assert.Equal(t, "1.5s", server.Properties["timeout"].Default)
+}
+
+func TestGenerate_Comments(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("../conf/config.binpb")
+	require.NoError(t, err)
+
+	var set descriptorpb.FileDescriptorSet
+	require.NoError(t, proto.Unmarshal(data, &set))
+
+	files, err := protodesc.NewFiles(&set)
+	require.NoError(t, err)
+
+	desc, err := files.FindDescriptorByName(protoreflect.FullName("conf.v1.ConfigWithConstraints"))
+	require.NoError(t, err)
+
+	s := Generate(desc.(protoreflect.MessageDescriptor))
+
+	assert.Equal(t, "ConfigWithConstraints is a configuration with common validation constraints.", s.Description)
+	assert.Equal(t, "Name of the service.", s.Properties["name"].Description)
+	assert.Equal(t, "Mode is the run mode.", s.Properties["mode"].Description)
+}
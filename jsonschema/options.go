@@ -0,0 +1,25 @@
+package jsonschema
+
+// Option is generator option.
+type Option func(*options)
+
+type options struct {
+	jsonNames bool
+	strict    bool
+}
+
+// WithJSONNames names the properties with the lowerCamelCase JSON names of
+// the fields instead of their proto names. protojson accepts both.
+func WithJSONNames() Option {
+	return func(o *options) {
+		o.jsonNames = true
+	}
+}
+
+// WithStrict disallows the properties which are not fields of the message,
+// like the loader created with protoconf.WithStrict.
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
@@ -0,0 +1,51 @@
+// Package jsonschema generates JSON Schema Draft 2020-12 documents from
+// configuration message descriptors, so editors and YAML language servers
+// can validate the configuration files as they are typed.
+package jsonschema
+
+// Draft is the URI of the JSON Schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema. Only the keywords used by the generator
+// are supported.
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Deprecated  bool               `json:"deprecated,omitempty"`
+	// Type is either a string or a list of strings.
+	Type    interface{}   `json:"type,omitempty"`
+	Const   interface{}   `json:"const,omitempty"`
+	Enum    []interface{} `json:"enum,omitempty"`
+	Default interface{}   `json:"default,omitempty"`
+
+	Format           string   `json:"format,omitempty"`
+	Pattern          string   `json:"pattern,omitempty"`
+	ContentEncoding  string   `json:"contentEncoding,omitempty"`
+	MinLength        *uint64  `json:"minLength,omitempty"`
+	MaxLength        *uint64  `json:"maxLength,omitempty"`
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+
+	Items       *Schema `json:"items,omitempty"`
+	MinItems    *uint64 `json:"minItems,omitempty"`
+	MaxItems    *uint64 `json:"maxItems,omitempty"`
+	UniqueItems bool    `json:"uniqueItems,omitempty"`
+
+	Properties    map[string]*Schema `json:"properties,omitempty"`
+	Required      []string           `json:"required,omitempty"`
+	PropertyNames *Schema            `json:"propertyNames,omitempty"`
+	// AdditionalProperties is either a *Schema or a bool.
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+	MinProperties        *uint64     `json:"minProperties,omitempty"`
+	MaxProperties        *uint64     `json:"maxProperties,omitempty"`
+
+	AllOf []*Schema `json:"allOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	OneOf []*Schema `json:"oneOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+}
@@ -0,0 +1,324 @@
+package merge
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/gosynergy/protoconf/internal/schema"
+	protoconfpb "github.com/gosynergy/protoconf/protoconf"
+)
+
+var ErrNoKey = errors.New("no merge key")
+
+// MapStrategy is the merge strategy for maps.
+type MapStrategy int
+
+const (
+	// MapDefault falls back to the next rule.
+	MapDefault MapStrategy = iota
+	// MapMerge merges the maps recursively.
+	MapMerge
+	// MapReplace replaces the whole map.
+	MapReplace
+)
+
+// ListStrategy is the merge strategy for lists.
+type ListStrategy int
+
+const (
+	// ListDefault falls back to the next rule.
+	ListDefault ListStrategy = iota
+	// ListReplace replaces the whole list.
+	ListReplace
+	// ListAppend appends the elements to the list.
+	ListAppend
+	// ListMergeByKey merges the map elements having the same key value
+	// and appends the others.
+	ListMergeByKey
+)
+
+// Rule describes how conflicting values are merged.
+type Rule struct {
+	Map  MapStrategy
+	List ListStrategy
+	// Key is the name of the element key used by ListMergeByKey.
+	Key string
+}
+
+// Merger merges configuration values using the configured strategies.
+// By default maps are merged recursively and lists are replaced.
+type Merger struct {
+	opts options
+}
+
+// New creates a new Merger.
+func New(opts ...Option) *Merger {
+	mergeOpts := options{
+		rule: Rule{
+			Map:  MapMerge,
+			List: ListReplace,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&mergeOpts)
+	}
+
+	return &Merger{
+		opts: mergeOpts,
+	}
+}
+
+// Merge merges src into dst and returns the result. The src maps are copied,
+// so they are never shared with the result.
+func (m *Merger) Merge(dst, src map[string]interface{}) (map[string]interface{}, error) {
+	return m.mergeMap("", scope{message: m.opts.schema}, dst, src)
+}
+
+func (m *Merger) mergeMap(
+	path string,
+	sc scope,
+	dst, src map[string]interface{},
+) (map[string]interface{}, error) {
+	if dst == nil {
+		dst = make(map[string]interface{}, len(src))
+	}
+
+	for key, srcValue := range src {
+		fieldPath := join(path, key)
+		fd := sc.field(key)
+
+		value, err := m.mergeValue(fieldPath, fd, dst[key], srcValue)
+		if err != nil {
+			return nil, err
+		}
+
+		dst[key] = value
+	}
+
+	return dst, nil
+}
+
+func (m *Merger) mergeValue(
+	path string,
+	fd protoreflect.FieldDescriptor,
+	dstValue, srcValue interface{},
+) (interface{}, error) {
+	rule := m.rule(path, fd)
+
+	switch src := srcValue.(type) {
+	case map[string]interface{}:
+		dstMap, ok := dstValue.(map[string]interface{})
+		if !ok || rule.Map == MapReplace {
+			dstMap = nil
+		}
+
+		return m.mergeMap(path, childScope(fd), dstMap, src)
+	case []interface{}:
+		dstList, ok := dstValue.([]interface{})
+		if !ok {
+			dstList = nil
+		}
+
+		return m.mergeList(path, fd, rule, dstList, src)
+	default:
+		return srcValue, nil
+	}
+}
+
+func (m *Merger) mergeList(
+	path string,
+	fd protoreflect.FieldDescriptor,
+	rule Rule,
+	dst, src []interface{},
+) ([]interface{}, error) {
+	var err error
+
+	switch rule.List {
+	case ListAppend:
+		merged := make([]interface{}, 0, len(dst)+len(src))
+		merged = append(merged, dst...)
+
+		for _, srcValue := range src {
+			value, err := m.copyValue(path, fd, srcValue)
+			if err != nil {
+				return nil, err
+			}
+
+			merged = append(merged, value)
+		}
+
+		return merged, nil
+	case ListMergeByKey:
+		if rule.Key == "" {
+			return nil, fmt.Errorf("%s: %w", path, ErrNoKey)
+		}
+
+		merged := make([]interface{}, len(dst), len(dst)+len(src))
+		copy(merged, dst)
+
+		for _, srcValue := range src {
+			idx := indexByKey(merged, rule.Key, srcValue)
+			if idx < 0 {
+				srcValue, err = m.copyValue(path, fd, srcValue)
+				if err != nil {
+					return nil, err
+				}
+
+				merged = append(merged, srcValue)
+
+				continue
+			}
+
+			merged[idx], err = m.mergeValue(path, fd, merged[idx], srcValue)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return merged, nil
+	case ListDefault, ListReplace:
+	}
+
+	merged := make([]interface{}, 0, len(src))
+
+	for _, srcValue := range src {
+		value, err := m.copyValue(path, fd, srcValue)
+		if err != nil {
+			return nil, err
+		}
+
+		merged = append(merged, value)
+	}
+
+	return merged, nil
+}
+
+func (m *Merger) copyValue(path string, fd protoreflect.FieldDescriptor, value interface{}) (interface{}, error) {
+	return m.mergeValue(path, fd, nil, value)
+}
+
+// rule returns the rule for the value at the path. Unset strategies of
+// the path rule are taken from the schema, then from the defaults.
+func (m *Merger) rule(path string, fd protoreflect.FieldDescriptor) Rule {
+	rules := []Rule{m.opts.fields[path], schemaRule(fd), m.opts.rule}
+
+	var rule Rule
+
+	for _, r := range rules {
+		if rule.Map == MapDefault {
+			rule.Map = r.Map
+		}
+
+		if rule.List == ListDefault {
+			rule.List = r.List
+			rule.Key = r.Key
+		}
+	}
+
+	return rule
+}
+
+func schemaRule(fd protoreflect.FieldDescriptor) Rule {
+	if fd == nil {
+		return Rule{}
+	}
+
+	mergeOpts := schema.Options(fd).GetMerge()
+	if mergeOpts == nil {
+		return Rule{}
+	}
+
+	rule := Rule{
+		Key: mergeOpts.GetKey(),
+	}
+
+	switch mergeOpts.GetMap() {
+	case protoconfpb.MapStrategy_MAP_STRATEGY_MERGE:
+		rule.Map = MapMerge
+	case protoconfpb.MapStrategy_MAP_STRATEGY_REPLACE:
+		rule.Map = MapReplace
+	case protoconfpb.MapStrategy_MAP_STRATEGY_UNSPECIFIED:
+	}
+
+	switch mergeOpts.GetList() {
+	case protoconfpb.ListStrategy_LIST_STRATEGY_REPLACE:
+		rule.List = ListReplace
+	case protoconfpb.ListStrategy_LIST_STRATEGY_APPEND:
+		rule.List = ListAppend
+	case protoconfpb.ListStrategy_LIST_STRATEGY_MERGE_BY_KEY:
+		rule.List = ListMergeByKey
+	case protoconfpb.ListStrategy_LIST_STRATEGY_UNSPECIFIED:
+	}
+
+	return rule
+}
+
+func indexByKey(list []interface{}, key string, value interface{}) int {
+	valueMap, ok := value.(map[string]interface{})
+	if !ok {
+		return -1
+	}
+
+	keyValue, ok := valueMap[key]
+	if !ok {
+		return -1
+	}
+
+	for i, elem := range list {
+		elemMap, ok := elem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		elemKey, ok := elemMap[key]
+		if ok && reflect.DeepEqual(elemKey, keyValue) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// scope resolves the field descriptors of the map keys.
+type scope struct {
+	// message is the descriptor of the message the keys are fields of.
+	message protoreflect.MessageDescriptor
+	// mapValue is the descriptor of the map field values.
+	mapValue protoreflect.FieldDescriptor
+}
+
+func (s scope) field(key string) protoreflect.FieldDescriptor {
+	if s.mapValue != nil {
+		return s.mapValue
+	}
+
+	if s.message == nil {
+		return nil
+	}
+
+	return schema.FieldByKey(s.message, key)
+}
+
+func childScope(fd protoreflect.FieldDescriptor) scope {
+	if fd == nil {
+		return scope{}
+	}
+
+	if fd.IsMap() {
+		return scope{mapValue: fd.MapValue()}
+	}
+
+	return scope{message: fd.Message()}
+}
@@ -0,0 +1,175 @@
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+)
+
+func TestMerger_Merge(t *testing.T) {
+	t.Parallel()
+
+	dst := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": 1,
+			"c": []interface{}{1, 2},
+		},
+		"d": "base",
+		"e": map[string]interface{}{
+			"f": 1,
+		},
+	}
+	src := map[string]interface{}{
+		"a": map[string]interface{}{
+			"c": []interface{}{3},
+			"g": true,
+		},
+		"e": "scalar",
+		"h": map[string]interface{}{
+			"i": nil,
+		},
+	}
+
+	merged, err := New().Merge(dst, src)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": 1,
+			"c": []interface{}{3},
+			"g": true,
+		},
+		"d": "base",
+		"e": "scalar",
+		"h": map[string]interface{}{
+			"i": nil,
+		},
+	}, merged)
+}
+
+func TestMerger_Merge_DoesNotShareSourceMaps(t *testing.T) {
+	t.Parallel()
+
+	merger := New()
+	first := map[string]interface{}{
+		"a": map[string]interface{}{"b": 1},
+	}
+	second := map[string]interface{}{
+		"a": map[string]interface{}{"c": 2},
+	}
+
+	merged, err := merger.Merge(nil, first)
+	require.NoError(t, err)
+
+	merged, err = merger.Merge(merged, second)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"b": 1}, first["a"])
+	assert.Equal(t, map[string]interface{}{"b": 1, "c": 2}, merged["a"])
+}
+
+func TestMerger_Merge_WithDefaultStrategies(t *testing.T) {
+	t.Parallel()
+
+	merger := New(
+		WithMapStrategy(MapReplace),
+		WithListStrategy(ListAppend, ""),
+	)
+
+	merged, err := merger.Merge(
+		map[string]interface{}{
+			"a": map[string]interface{}{"b": 1},
+			"l": []interface{}{1},
+		},
+		map[string]interface{}{
+			"a": map[string]interface{}{"c": 2},
+			"l": []interface{}{2},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"a": map[string]interface{}{"c": 2},
+		"l": []interface{}{1, 2},
+	}, merged)
+}
+
+func TestMerger_Merge_WithSchema(t *testing.T) {
+	t.Parallel()
+
+	merger := New(WithSchema((&v1.ConfigWithMerge{}).ProtoReflect().Descriptor()))
+
+	merged, err := merger.Merge(
+		map[string]interface{}{
+			"listeners": []interface{}{
+				map[string]interface{}{
+					"name":   "http",
+					"addr":   ":80",
+					"labels": map[string]interface{}{"a": "1"},
+				},
+				map[string]interface{}{"name": "grpc", "addr": ":9000"},
+			},
+			"tags":   []interface{}{"base"},
+			"labels": map[string]interface{}{"a": "1"},
+		},
+		map[string]interface{}{
+			"listeners": []interface{}{
+				map[string]interface{}{
+					"name":   "http",
+					"addr":   ":8080",
+					"labels": map[string]interface{}{"b": "2"},
+				},
+				map[string]interface{}{"name": "admin", "addr": ":9090"},
+			},
+			"tags":   []interface{}{"prod"},
+			"labels": map[string]interface{}{"b": "2"},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"listeners": []interface{}{
+			map[string]interface{}{
+				"name":   "http",
+				"addr":   ":8080",
+				"labels": map[string]interface{}{"a": "1", "b": "2"},
+			},
+			map[string]interface{}{"name": "grpc", "addr": ":9000"},
+			map[string]interface{}{"name": "admin", "addr": ":9090"},
+		},
+		"tags":   []interface{}{"base", "prod"},
+		"labels": map[string]interface{}{"b": "2"},
+	}, merged)
+}
+
+func TestMerger_Merge_WithFieldOverridesSchema(t *testing.T) {
+	t.Parallel()
+
+	merger := New(
+		WithSchema((&v1.ConfigWithMerge{}).ProtoReflect().Descriptor()),
+		WithField("tags", Rule{List: ListReplace}),
+	)
+
+	merged, err := merger.Merge(
+		map[string]interface{}{"tags": []interface{}{"base"}},
+		map[string]interface{}{"tags": []interface{}{"prod"}},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"tags": []interface{}{"prod"}}, merged)
+}
+
+func TestMerger_Merge_MergeByKeyWithoutKey(t *testing.T) {
+	t.Parallel()
+
+	merger := New(WithField("items", Rule{List: ListMergeByKey}))
+
+	_, err := merger.Merge(
+		map[string]interface{}{"items": []interface{}{}},
+		map[string]interface{}{"items": []interface{}{}},
+	)
+	require.ErrorIs(t, err, ErrNoKey)
+}
@@ -0,0 +1,51 @@
+package merge
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Option is merger option.
+type Option func(*options)
+
+type options struct {
+	rule   Rule
+	fields map[string]Rule
+	schema protoreflect.MessageDescriptor
+}
+
+// WithMapStrategy sets the default strategy for maps.
+func WithMapStrategy(s MapStrategy) Option {
+	return func(opts *options) {
+		opts.rule.Map = s
+	}
+}
+
+// WithListStrategy sets the default strategy for lists. The key is used
+// by ListMergeByKey only.
+func WithListStrategy(s ListStrategy, key string) Option {
+	return func(opts *options) {
+		opts.rule.List = s
+		opts.rule.Key = key
+	}
+}
+
+// WithField overrides the rule for the value at the dotted path, e.g.
+// `server.listeners`. List elements share the path of the list.
+// The rule takes precedence over the schema field options.
+func WithField(path string, rule Rule) Option {
+	return func(opts *options) {
+		if opts.fields == nil {
+			opts.fields = make(map[string]Rule)
+		}
+
+		opts.fields[path] = rule
+	}
+}
+
+// WithSchema sets the configuration message descriptor. The rules are then
+// taken from the `(protoconf.field).merge` options of the message fields.
+func WithSchema(desc protoreflect.MessageDescriptor) Option {
+	return func(opts *options) {
+		opts.schema = desc
+	}
+}
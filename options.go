@@ -1,5 +1,10 @@
 package protoconf
 
+import (
+	"context"
+	"strings"
+)
+
 // Option is config option.
 type Option func(*options)
 
@@ -16,39 +21,257 @@ type Provider interface {
 	Read() (map[string]interface{}, error)
 }
 
+// ContextProvider is implemented by providers supporting cancellation.
+// The loader prefers these methods to the Provider ones.
+type ContextProvider interface {
+	// ReadBytesContext is ReadBytes with a context.
+	ReadBytesContext(ctx context.Context) ([]byte, error)
+
+	// ReadContext is Read with a context.
+	ReadContext(ctx context.Context) (map[string]interface{}, error)
+}
+
+// FileProvider is implemented by providers reading a file.
+type FileProvider interface {
+	// Path returns the path of the file.
+	Path() string
+}
+
+// ContentTypeProvider is implemented by providers knowing the content type
+// of their data, e.g. `application/json` from an HTTP response. The content
+// type is read after the data.
+type ContentTypeProvider interface {
+	// ContentType returns the content type of the data.
+	ContentType() string
+}
+
+// Named is implemented by providers and transformers having a name.
+// The name is used to explain the origin of the values.
+type Named interface {
+	Name() string
+}
+
 // Parser represents a configuration format parser.
 type Parser interface {
 	Unmarshal(data []byte) (map[string]interface{}, error)
 }
 
+// Position is a position in a configuration file.
+type Position struct {
+	// Line is the 1-based line number.
+	Line int
+	// Column is the 1-based column number.
+	Column int
+}
+
+// PositionParser is implemented by parsers reporting the positions
+// of the values. The loader prefers UnmarshalWithPositions to Unmarshal.
+type PositionParser interface {
+	// UnmarshalWithPositions returns the parsed configuration and
+	// the positions of the values by their dotted path.
+	UnmarshalWithPositions(data []byte) (map[string]interface{}, map[string]Position, error)
+}
+
 // Transformer transforms the configuration values.
+//
+// A transformer may return the values it could transform with its error,
+// a loader created with WithCollectErrors keeps them. The failed values are
+// located by the PathError errors, the error may join several of them.
 type Transformer interface {
 	Transform(values map[string]interface{}) (map[string]interface{}, error)
 }
 
+// PathError is implemented by the transformer errors of a single value.
+// A loader created with WithCollectErrors reports the error at the value
+// and does not check the value, which the transformer could not rewrite.
+type PathError interface {
+	error
+	// ValuePath returns the dotted path of the value, e.g. `server.http.addr`.
+	ValuePath() string
+	// Unwrap returns the error without the path.
+	Unwrap() error
+}
+
+// ContextTransformer is implemented by transformers supporting cancellation.
+// The loader prefers TransformContext to Transform.
+type ContextTransformer interface {
+	TransformContext(ctx context.Context, values map[string]interface{}) (map[string]interface{}, error)
+}
+
+// Merger merges the configuration values of a provider into the values of
+// the previous providers.
+type Merger interface {
+	Merge(dst, src map[string]interface{}) (map[string]interface{}, error)
+}
+
+// layer is a single configuration source.
+type layer struct {
+	provider Provider
+	parser   Parser
+}
+
 type options struct {
-	provider     Provider
+	layers       []layer
 	parser       Parser
 	transformers []Transformer
+	merger       Merger
+	strict       bool
+	profiles     []string
+
+	includes       bool
+	includeParsers map[string]Parser
+
+	collectErrors bool
+
+	registry    *Registry
+	registrySet bool
+
+	watchErrorHandler func(error)
 }
 
-// WithProvider sets the configuration provider.
+// WithProvider adds a configuration provider. The provider data is parsed with
+// the parser set by WithParser.
+//
+// Providers are read in the order they are added and their values are merged
+// into a single configuration with the Merger set by WithMerger. By default
+// nested maps are merged recursively, while any other value (scalar, list or
+// null) of a later provider replaces the value of an earlier one.
 func WithProvider(p Provider) Option {
 	return func(o *options) {
-		o.provider = p
+		o.layers = append(o.layers, layer{provider: p})
+	}
+}
+
+// WithLayer adds a configuration provider with its own parser, taking
+// precedence over the parser set by WithParser. Layers are merged in the
+// same order as the providers added by WithProvider.
+func WithLayer(p Provider, parser Parser) Option {
+	return func(o *options) {
+		o.layers = append(o.layers, layer{provider: p, parser: parser})
 	}
 }
 
-// WithParser sets the configuration parser.
+// WithParser sets the configuration parser. Without a parser, the parser
+// of every provider is selected by the registry set by WithParserRegistry.
 func WithParser(p Parser) Option {
 	return func(o *options) {
 		o.parser = p
 	}
 }
 
+// WithParserRegistry sets the registry selecting the parser of
+// the providers without a parser, DefaultRegistry by default:
+//
+//   - the providers implementing FileProvider are parsed with the parser of
+//     their file extension, e.g. `.yaml`;
+//   - the providers implementing ContentTypeProvider are parsed with
+//     the parser of their content type, e.g. `application/json`;
+//   - the other providers are read with Read, like without a registry.
+//
+// The data of a provider is sniffed, see Detect, if its file extension or
+// content type is unknown, or if its Read fails. A nil registry disables
+// the selection, the providers without a parser are read with Read.
+func WithParserRegistry(r *Registry) Option {
+	return func(o *options) {
+		o.registry = r
+		o.registrySet = true
+	}
+}
+
 // WithTransformers sets the configuration transformers.
 func WithTransformers(t ...Transformer) Option {
 	return func(o *options) {
 		o.transformers = append(o.transformers, t...)
 	}
 }
+
+// WithMerger sets the merger used to combine the values of the providers.
+func WithMerger(m Merger) Option {
+	return func(o *options) {
+		o.merger = m
+	}
+}
+
+// WithWatchErrorHandler sets the handler of the errors occurred while
+// reloading a watched configuration.
+func WithWatchErrorHandler(fn func(error)) Option {
+	return func(o *options) {
+		o.watchErrorHandler = fn
+	}
+}
+
+// WithStrict makes Scan fail on configuration keys which are not fields
+// of the message. Every unknown key is reported as an UnknownFieldError.
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// WithProfiles activates the configuration profiles, e.g. `prod` and
+// `eu-west`. The profiles of every layer are merged in order over the layer,
+// before the next layer and the transformers:
+//
+//   - the `profiles.<profile>` section of the layer values, e.g.
+//     `profiles: {prod: {server: {addr: ":80"}}}`;
+//   - the `<name>.<profile>.<ext>` sibling of the layer file, e.g.
+//     `conf/config.prod.yaml` for `conf/config.yaml`, if it exists. It is
+//     parsed with the parser of the layer.
+//
+// With WithProfiles the `profiles` section is removed from the values, even
+// if none of its profiles is active.
+func WithProfiles(profiles ...string) Option {
+	return func(o *options) {
+		if o.profiles == nil {
+			o.profiles = []string{}
+		}
+
+		o.profiles = append(o.profiles, profiles...)
+	}
+}
+
+// WithIncludes resolves the include directives of the configuration files:
+// a `$include` key, whose value is a path or a list of paths, is replaced
+// with the values of the included files, merged under the other keys of
+// its map:
+//
+//	data:
+//	  redis:
+//	    $include: ./common/redis.yaml
+//	    db: 2
+//
+// The paths are relative to the including file, or to the working
+// directory for the providers which are not files. The included files can
+// include other files, a cycle is an error. They are parsed with the parser
+// set by WithIncludeParser for their extension, the parser of the layer if
+// they have the extension of the layer file, or the parser of the registry
+// for their extension, see WithParserRegistry.
+func WithIncludes() Option {
+	return func(o *options) {
+		o.includes = true
+	}
+}
+
+// WithIncludeParser sets the parser of the included files with
+// the extension, e.g. `.json`, see WithIncludes.
+func WithIncludeParser(ext string, p Parser) Option {
+	return func(o *options) {
+		if o.includeParsers == nil {
+			o.includeParsers = make(map[string]Parser)
+		}
+
+		o.includeParsers[strings.ToLower(ext)] = p
+	}
+}
+
+// WithCollectErrors makes the loader report every problem at once instead of
+// stopping at the first one. Load skips the providers and transformers which
+// fail, or only the values of a transformer located by its PathError errors,
+// and Scan skips the values which cannot be unmarshalled, so the rest of
+// the configuration is still checked. Scan returns all the errors, including
+// the ones of Load, as a *MultiError.
+func WithCollectErrors() Option {
+	return func(o *options) {
+		o.collectErrors = true
+	}
+}
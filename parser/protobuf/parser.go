@@ -0,0 +1,76 @@
+// Package protobuf provides the parsers of the configuration messages in
+// the protobuf text and binary formats.
+package protobuf
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/gosynergy/protoconf"
+)
+
+// Parser parses a configuration message in the protobuf text or binary
+// format. The message is converted to the nested map of its protojson
+// representation with the proto field names, so it is merged with
+// the configurations in the other formats.
+type Parser struct {
+	desc      protoreflect.MessageDescriptor
+	unmarshal func(data []byte, message proto.Message) error
+}
+
+var _ protoconf.Parser = (*Parser)(nil)
+
+// Text creates a parser of the message in the protobuf text format, e.g.
+// `server { http { addr: ":8080" } }`.
+func Text(desc protoreflect.MessageDescriptor) *Parser {
+	return &Parser{
+		desc:      desc,
+		unmarshal: prototext.Unmarshal,
+	}
+}
+
+// Binary creates a parser of the message in the protobuf binary format.
+func Binary(desc protoreflect.MessageDescriptor) *Parser {
+	return &Parser{
+		desc:      desc,
+		unmarshal: proto.Unmarshal,
+	}
+}
+
+// Register registers the text and binary parsers of the message in
+// the registry, for the `.txtpb`, `.textproto`, `.pb` and `.binpb`
+// extensions and their content types.
+func Register(r *protoconf.Registry, desc protoreflect.MessageDescriptor) {
+	r.Register(Text(desc), protoconf.ExtProtoText, ".textproto", "text/x-protobuf", "application/x-protobuf-text")
+	r.Register(Binary(desc), protoconf.ExtProto, ".binpb", "application/x-protobuf", "application/protobuf")
+}
+
+// Unmarshal parses the message.
+func (p *Parser) Unmarshal(data []byte) (map[string]interface{}, error) {
+	message := dynamicpb.NewMessage(p.desc)
+
+	err := p.unmarshal(data, message)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", p.desc.FullName(), err)
+	}
+
+	jsonData, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("protojson marshal: %w", err)
+	}
+
+	var values map[string]interface{}
+
+	err = json.Unmarshal(jsonData, &values)
+	if err != nil {
+		return nil, fmt.Errorf("json unmarshal: %w", err)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,70 @@
+package protobuf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/gosynergy/protoconf"
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+	"github.com/gosynergy/protoconf/provider/file"
+)
+
+func TestParser_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	desc := (&v1.Config{}).ProtoReflect().Descriptor()
+
+	data, err := proto.Marshal(&v1.Config{
+		Server: &v1.Config_Server{
+			Http: &v1.Config_Server_Http{Addr: ":8080", Timeout: durationpb.New(2 * time.Second)},
+		},
+	})
+	require.NoError(t, err)
+
+	expected := map[string]interface{}{
+		"server": map[string]interface{}{
+			"http": map[string]interface{}{"addr": ":8080", "timeout": "2s"},
+		},
+	}
+
+	values, err := Binary(desc).Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, expected, values)
+
+	values, err = Text(desc).Unmarshal([]byte(`server { http { addr: ":8080" timeout { seconds: 2 } } }`))
+	require.NoError(t, err)
+	assert.Equal(t, expected, values)
+
+	_, err = Text(desc).Unmarshal([]byte(`server { unknown: 1 }`))
+	require.Error(t, err)
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	registry := protoconf.DefaultRegistry()
+	Register(registry, (&v1.Config{}).ProtoReflect().Descriptor())
+
+	loader, err := protoconf.New(
+		protoconf.WithProvider(file.Provider("../../conf/config.txtpb")),
+		protoconf.WithParserRegistry(registry),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.Config
+	require.NoError(t, loader.Scan(&cfg))
+
+	assert.Equal(t, "127.0.0.1:8080", cfg.GetServer().GetHttp().GetAddr())
+	assert.Equal(t, 2*time.Second, cfg.GetServer().GetHttp().GetTimeout().AsDuration())
+	assert.Equal(t, "mysql", cfg.GetData().GetDatabase().GetDriver())
+
+	parser, ok := registry.Sniff([]byte("server {\n}\n"))
+	require.True(t, ok)
+	assert.IsType(t, &Parser{}, parser)
+}
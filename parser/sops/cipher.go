@@ -0,0 +1,82 @@
+package sops
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var (
+	ErrInvalidValue = errors.New("invalid encrypted value")
+	ErrUnknownType  = errors.New("unknown value type")
+)
+
+var encryptedValue = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.*),iv:(.+),tag:(.+),type:(.+)\]$`)
+
+// isEncrypted reports whether the value is a SOPS encrypted value.
+func isEncrypted(value interface{}) bool {
+	s, ok := value.(string)
+
+	return ok && encryptedValue.MatchString(s)
+}
+
+// decrypt decrypts the SOPS encrypted value, e.g.
+// `ENC[AES256_GCM,data:...,iv:...,tag:...,type:str]`, with the data key.
+// The additional data is the path of the value, e.g. `server:http:addr:`.
+func decrypt(value string, key []byte, additionalData string) (interface{}, error) {
+	matches := encryptedValue.FindStringSubmatch(value)
+	if matches == nil {
+		return nil, ErrInvalidValue
+	}
+
+	parts := make([][]byte, 3)
+
+	for i, part := range matches[1:4] {
+		decoded, err := base64.StdEncoding.DecodeString(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidValue, err)
+		}
+
+		parts[i] = decoded
+	}
+
+	data, iv, tag := parts[0], parts[1], parts[2]
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("aes: %w", err)
+	}
+
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	if err != nil {
+		return nil, fmt.Errorf("gcm: %w", err)
+	}
+
+	plaintext, err := gcm.Open(nil, iv, append(data, tag...), []byte(additionalData))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidValue, err)
+	}
+
+	return convert(string(plaintext), matches[4])
+}
+
+// convert converts the plaintext to the type of the value before
+// the encryption.
+func convert(plaintext, valueType string) (interface{}, error) {
+	switch valueType {
+	case "str", "bytes", "comment":
+		return plaintext, nil
+	case "int":
+		return strconv.Atoi(plaintext)
+	case "float":
+		return strconv.ParseFloat(plaintext, 64)
+	case "bool":
+		return strconv.ParseBool(plaintext)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, valueType)
+	}
+}
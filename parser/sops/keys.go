@@ -0,0 +1,108 @@
+package sops
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+var ErrNoIdentity = errors.New("no age identity decrypts the data key")
+
+// identities returns the identities set by the options, read from
+// the SOPS_AGE_KEY and SOPS_AGE_KEY_FILE variables and from the default
+// `sops/age/keys.txt` file of the user configuration directory, like sops,
+// unless WithoutDefaultKeyFile is set.
+func (p *SOPS) identities() ([]age.Identity, error) {
+	identities := append([]age.Identity(nil), p.opts.identities...)
+
+	keys, ok := p.opts.lookupEnv("SOPS_AGE_KEY")
+	if ok {
+		parsed, err := age.ParseIdentities(strings.NewReader(keys))
+		if err != nil {
+			return nil, fmt.Errorf("parse SOPS_AGE_KEY: %w", err)
+		}
+
+		identities = append(identities, parsed...)
+	}
+
+	keyFiles := p.opts.keyFiles
+
+	keyFile, ok := p.opts.lookupEnv("SOPS_AGE_KEY_FILE")
+	if ok {
+		keyFiles = append(keyFiles, keyFile)
+	}
+
+	for _, path := range keyFiles {
+		parsed, err := readIdentities(path)
+		if err != nil {
+			return nil, err
+		}
+
+		identities = append(identities, parsed...)
+	}
+
+	if p.opts.noDefault {
+		return identities, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err == nil {
+		parsed, err := readIdentities(filepath.Join(configDir, "sops", "age", "keys.txt"))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		identities = append(identities, parsed...)
+	}
+
+	return identities, nil
+}
+
+func readIdentities(path string) ([]age.Identity, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open key file: %w", err)
+	}
+	defer f.Close()
+
+	identities, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse key file %s: %w", path, err)
+	}
+
+	return identities, nil
+}
+
+// dataKey decrypts the data key of the file with the first identity
+// matching one of its age recipients.
+func dataKey(md *metadata, identities []age.Identity) ([]byte, error) {
+	if len(identities) == 0 {
+		return nil, ErrNoIdentity
+	}
+
+	var errs []error
+
+	for _, enc := range md.age {
+		r, err := age.Decrypt(armor.NewReader(strings.NewReader(enc)), identities...)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		key, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("read data key: %w", err)
+		}
+
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrNoIdentity, errors.Join(errs...))
+}
@@ -0,0 +1,51 @@
+package sops
+
+import (
+	"errors"
+	"fmt"
+)
+
+// metadataKey is the key of the SOPS metadata in the document.
+const metadataKey = "sops"
+
+var ErrInvalidMetadata = errors.New("invalid sops metadata")
+
+// metadata is the part of the SOPS metadata needed to decrypt a file
+// with age.
+type metadata struct {
+	age              []string
+	lastModified     string
+	mac              string
+	macOnlyEncrypted bool
+}
+
+func parseMetadata(value interface{}) (*metadata, error) {
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: not a mapping", ErrInvalidMetadata)
+	}
+
+	md := &metadata{}
+
+	recipients, _ := values["age"].([]interface{})
+	for i, recipient := range recipients {
+		entry, _ := recipient.(map[string]interface{})
+
+		enc, ok := entry["enc"].(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: age[%d].enc is not a string", ErrInvalidMetadata, i)
+		}
+
+		md.age = append(md.age, enc)
+	}
+
+	if len(md.age) == 0 {
+		return nil, fmt.Errorf("%w: no age recipients", ErrInvalidMetadata)
+	}
+
+	md.lastModified, _ = values["lastmodified"].(string)
+	md.mac, _ = values["mac"].(string)
+	md.macOnlyEncrypted, _ = values["mac_only_encrypted"].(bool)
+
+	return md, nil
+}
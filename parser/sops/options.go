@@ -0,0 +1,55 @@
+package sops
+
+import "filippo.io/age"
+
+// Option is parser option.
+type Option func(*options)
+
+type options struct {
+	identities []age.Identity
+	keyFiles   []string
+	lookupEnv  func(string) (string, bool)
+	skipMAC    bool
+	noDefault  bool
+}
+
+// WithIdentities adds the age identities used to decrypt the data key.
+func WithIdentities(identities ...age.Identity) Option {
+	return func(opts *options) {
+		opts.identities = append(opts.identities, identities...)
+	}
+}
+
+// WithKeyFile adds a file of age identities, in the `age-keygen` format,
+// used to decrypt the data key.
+func WithKeyFile(path string) Option {
+	return func(opts *options) {
+		opts.keyFiles = append(opts.keyFiles, path)
+	}
+}
+
+// WithLookupEnv sets the function used to look up the SOPS_AGE_KEY and
+// SOPS_AGE_KEY_FILE variables. The default is os.LookupEnv.
+func WithLookupEnv(lookupEnv func(string) (string, bool)) Option {
+	return func(opts *options) {
+		opts.lookupEnv = lookupEnv
+	}
+}
+
+// WithoutDefaultKeyFile disables the default `sops/age/keys.txt` file of
+// the user configuration directory, so the decryption does not depend on
+// the home directory of the machine, e.g. in the tests.
+func WithoutDefaultKeyFile() Option {
+	return func(opts *options) {
+		opts.noDefault = true
+	}
+}
+
+// WithoutMACCheck disables the verification of the SOPS message
+// authentication code, e.g. for the files with encrypted comments, which
+// are not part of the parsed values.
+func WithoutMACCheck() Option {
+	return func(opts *options) {
+		opts.skipMAC = true
+	}
+}
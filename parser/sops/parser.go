@@ -0,0 +1,304 @@
+// Package sops provides a parser decorator decrypting the configuration files
+// encrypted by sops (https://github.com/getsops/sops) with age keys.
+package sops
+
+import (
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gosynergy/protoconf"
+)
+
+var ErrMACMismatch = errors.New("sops mac mismatch")
+
+// SOPS is a parser decrypting the values of the documents encrypted by
+// sops. The document is parsed by the decorated parser, so the encrypted
+// files keep their format; the documents without the `sops` metadata are
+// returned as is.
+//
+// Only the values are encrypted by sops, the keys and the structure are
+// kept in plaintext, so the diffs of the encrypted files stay readable.
+// The data key is decrypted locally with the age identities, no key service
+// is involved.
+type SOPS struct {
+	parser protoconf.Parser
+	opts   options
+}
+
+var _ protoconf.PositionParser = (*SOPS)(nil)
+
+// Parser creates a new parser decrypting the documents parsed by parser.
+//
+// The age identities are set by WithIdentities and WithKeyFile, and are
+// read like sops from the SOPS_AGE_KEY variable, the file named by
+// the SOPS_AGE_KEY_FILE variable and the `sops/age/keys.txt` file of
+// the user configuration directory, see WithoutDefaultKeyFile.
+func Parser(parser protoconf.Parser, opts ...Option) *SOPS {
+	parserOpts := options{
+		lookupEnv: os.LookupEnv,
+	}
+
+	for _, opt := range opts {
+		opt(&parserOpts)
+	}
+
+	return &SOPS{
+		parser: parser,
+		opts:   parserOpts,
+	}
+}
+
+// Unmarshal parses and decrypts the document.
+func (p *SOPS) Unmarshal(data []byte) (map[string]interface{}, error) {
+	values, _, err := p.UnmarshalWithPositions(data)
+
+	return values, err
+}
+
+// UnmarshalWithPositions parses and decrypts the document. The positions
+// are reported if the decorated parser is a protoconf.PositionParser.
+func (p *SOPS) UnmarshalWithPositions(data []byte) (map[string]interface{}, map[string]protoconf.Position, error) {
+	values, positions, err := p.parse(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rawMetadata, ok := values[metadataKey]
+	if !ok {
+		return values, positions, nil
+	}
+
+	md, err := parseMetadata(rawMetadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	identities, err := p.identities()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := dataKey(md, identities)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !p.opts.skipMAC {
+		err = verifyMAC(data, key, md)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	delete(values, metadataKey)
+
+	for path := range positions {
+		if path == metadataKey || strings.HasPrefix(path, metadataKey+".") || strings.HasPrefix(path, metadataKey+"[") {
+			delete(positions, path)
+		}
+	}
+
+	decrypted, err := decryptValue(values, nil, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return decrypted.(map[string]interface{}), positions, nil
+}
+
+func (p *SOPS) parse(data []byte) (map[string]interface{}, map[string]protoconf.Position, error) {
+	positionParser, ok := p.parser.(protoconf.PositionParser)
+	if ok {
+		return positionParser.UnmarshalWithPositions(data)
+	}
+
+	values, err := p.parser.Unmarshal(data)
+
+	return values, nil, err
+}
+
+// decryptValue decrypts the encrypted values of the tree. The additional
+// data of a value is its path of keys, the list indexes are not part of it.
+func decryptValue(value interface{}, path []string, key []byte) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		decrypted := make(map[string]interface{}, len(v))
+
+		for k, elem := range v {
+			elemValue, err := decryptValue(elem, append(path[:len(path):len(path)], k), key)
+			if err != nil {
+				return nil, err
+			}
+
+			decrypted[k] = elemValue
+		}
+
+		return decrypted, nil
+	case []interface{}:
+		decrypted := make([]interface{}, len(v))
+
+		for i, elem := range v {
+			elemValue, err := decryptValue(elem, path, key)
+			if err != nil {
+				return nil, err
+			}
+
+			decrypted[i] = elemValue
+		}
+
+		return decrypted, nil
+	case string:
+		if !isEncrypted(v) {
+			return v, nil
+		}
+
+		decrypted, err := decrypt(v, key, additionalData(path))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", strings.Join(path, "."), err)
+		}
+
+		return decrypted, nil
+	default:
+		return v, nil
+	}
+}
+
+func additionalData(path []string) string {
+	return strings.Join(path, ":") + ":"
+}
+
+// verifyMAC checks the message authentication code of the document, which
+// is the SHA-512 of the values in the document order. The document is read
+// as YAML, which also covers JSON.
+func verifyMAC(data []byte, key []byte, md *metadata) error {
+	if md.mac == "" {
+		return fmt.Errorf("%w: no mac", ErrMACMismatch)
+	}
+
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return fmt.Errorf("yaml unmarshal: %w", err)
+	}
+
+	h := &hasher{
+		hash:          sha512.New(),
+		key:           key,
+		onlyEncrypted: md.macOnlyEncrypted,
+	}
+
+	err = h.walk(&doc, nil)
+	if err != nil {
+		return err
+	}
+
+	mac, err := decrypt(md.mac, key, md.lastModified)
+	if err != nil {
+		return fmt.Errorf("decrypt mac: %w", err)
+	}
+
+	expected, _ := mac.(string)
+	if !strings.EqualFold(expected, fmt.Sprintf("%X", h.hash.Sum(nil))) {
+		return ErrMACMismatch
+	}
+
+	return nil
+}
+
+type hasher struct {
+	hash          hash.Hash
+	key           []byte
+	onlyEncrypted bool
+}
+
+func (h *hasher) walk(node *yaml.Node, path []string) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, content := range node.Content {
+			err := h.walk(content, path)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.AliasNode:
+		return h.walk(node.Alias, path)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			if len(path) == 0 && keyNode.Value == metadataKey {
+				continue
+			}
+
+			err := h.walk(valueNode, append(path[:len(path):len(path)], keyNode.Value))
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for _, elem := range node.Content {
+			err := h.walk(elem, path)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		return h.scalar(node, path)
+	}
+
+	return nil
+}
+
+func (h *hasher) scalar(node *yaml.Node, path []string) error {
+	var value interface{}
+
+	err := node.Decode(&value)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", node.Line, err)
+	}
+
+	encrypted := isEncrypted(value)
+	if encrypted {
+		value, err = decrypt(value.(string), h.key, additionalData(path))
+		if err != nil {
+			return fmt.Errorf("%s: %w", strings.Join(path, "."), err)
+		}
+	}
+
+	if h.onlyEncrypted && !encrypted {
+		return nil
+	}
+
+	_, err = h.hash.Write(toBytes(value))
+
+	return err
+}
+
+// toBytes returns the bytes of the value hashed by sops.
+func toBytes(value interface{}) []byte {
+	switch v := value.(type) {
+	case string:
+		return []byte(v)
+	case int:
+		return []byte(strconv.Itoa(v))
+	case float64:
+		return []byte(strconv.FormatFloat(v, 'f', -1, 64))
+	case bool:
+		if v {
+			return []byte("True")
+		}
+
+		return []byte("False")
+	case nil:
+		return nil
+	default:
+		return []byte(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,473 @@
+package sops
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/knadh/koanf/parsers/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gosynergy/protoconf"
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+	protoconfyaml "github.com/gosynergy/protoconf/parser/yaml"
+	"github.com/gosynergy/protoconf/provider/file"
+)
+
+const plaintext = `server:
+  http:
+    addr: 0.0.0.0:80
+data:
+  database:
+    driver: mysql
+    source: root:root@tcp(127.0.0.1:3306)/test
+  redis:
+    addr: 127.0.0.1:6379
+    read_timeout: 0.2s
+limits:
+  - 10
+  - 2.5
+  - true
+`
+
+func TestSOPS_UnmarshalWithPositions(t *testing.T) {
+	t.Parallel()
+
+	identity := newIdentity(t)
+	data := encrypt(t, plaintext, identity.Recipient())
+
+	values, positions, err := testParser(protoconfyaml.Parser(), WithIdentities(identity)).UnmarshalWithPositions(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"server": map[string]interface{}{
+			"http": map[string]interface{}{"addr": "0.0.0.0:80"},
+		},
+		"data": map[string]interface{}{
+			"database": map[string]interface{}{
+				"driver": "mysql",
+				"source": "root:root@tcp(127.0.0.1:3306)/test",
+			},
+			"redis": map[string]interface{}{
+				"addr":         "127.0.0.1:6379",
+				"read_timeout": "0.2s",
+			},
+		},
+		"limits": []interface{}{10, 2.5, true},
+	}, values)
+	assert.Equal(t, protoconf.Position{Line: 3, Column: 15}, positions["server.http.addr"])
+	assert.NotContains(t, positions, "sops")
+	assert.NotContains(t, positions, "sops.mac")
+}
+
+func TestSOPS_Unmarshal_Plaintext(t *testing.T) {
+	t.Parallel()
+
+	values, err := testParser(json.Parser()).Unmarshal([]byte(`{"server": {"http": {"addr": ":80"}}}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"server": map[string]interface{}{
+			"http": map[string]interface{}{"addr": ":80"},
+		},
+	}, values)
+}
+
+func TestSOPS_Unmarshal_Keys(t *testing.T) {
+	t.Parallel()
+
+	identity := newIdentity(t)
+	data := encrypt(t, plaintext, identity.Recipient())
+
+	keyFile := filepath.Join(t.TempDir(), "keys.txt")
+	require.NoError(t, os.WriteFile(keyFile, []byte("# test key\n"+identity.String()+"\n"), 0o600))
+
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{
+			name: "key file",
+			opts: []Option{WithKeyFile(keyFile)},
+		},
+		{
+			name: "SOPS_AGE_KEY",
+			opts: []Option{WithLookupEnv(lookupEnv(map[string]string{"SOPS_AGE_KEY": identity.String()}))},
+		},
+		{
+			name: "SOPS_AGE_KEY_FILE",
+			opts: []Option{WithLookupEnv(lookupEnv(map[string]string{"SOPS_AGE_KEY_FILE": keyFile}))},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			values, err := testParser(protoconfyaml.Parser(), test.opts...).Unmarshal(data)
+			require.NoError(t, err)
+
+			assert.Equal(t, "mysql", values["data"].(map[string]interface{})["database"].(map[string]interface{})["driver"])
+		})
+	}
+}
+
+//nolint:paralleltest // t.Setenv
+func TestSOPS_Unmarshal_DefaultKeyFile(t *testing.T) {
+	identity := newIdentity(t)
+	data := encrypt(t, plaintext, identity.Recipient())
+
+	configDir := t.TempDir()
+	keyDir := filepath.Join(configDir, "sops", "age")
+	require.NoError(t, os.MkdirAll(keyDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(keyDir, "keys.txt"), []byte(identity.String()+"\n"), 0o600))
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+
+	noEnv := WithLookupEnv(lookupEnv(nil))
+
+	_, err := Parser(protoconfyaml.Parser(), noEnv).Unmarshal(data)
+	require.NoError(t, err)
+
+	_, err = Parser(protoconfyaml.Parser(), noEnv, WithoutDefaultKeyFile()).Unmarshal(data)
+	require.ErrorIs(t, err, ErrNoIdentity)
+}
+
+func TestSOPS_Unmarshal_Errors(t *testing.T) {
+	t.Parallel()
+
+	identity := newIdentity(t)
+	data := encrypt(t, plaintext, identity.Recipient())
+
+	swapped := swapValues(t, data, "driver", "addr")
+	removed := bytes.Replace(data, []byte("limits:\n"), []byte("limits:\n    - ENC[AES256_GCM,data:AA==,iv:AA==,tag:AA==,type:str]\n"), 1)
+
+	tests := []struct {
+		name string
+		data []byte
+		opts []Option
+		err  error
+	}{
+		{
+			name: "no identity",
+			data: data,
+			opts: []Option{WithLookupEnv(lookupEnv(nil))},
+			err:  ErrNoIdentity,
+		},
+		{
+			name: "wrong identity",
+			data: data,
+			opts: []Option{WithIdentities(newIdentity(t))},
+			err:  ErrNoIdentity,
+		},
+		{
+			name: "swapped values",
+			data: swapped,
+			opts: []Option{WithIdentities(identity), WithoutMACCheck()},
+			err:  ErrInvalidValue,
+		},
+		{
+			name: "invalid value",
+			data: removed,
+			opts: []Option{WithIdentities(identity)},
+			err:  ErrInvalidValue,
+		},
+		{
+			name: "invalid metadata",
+			data: []byte("sops: {}\n"),
+			opts: []Option{WithIdentities(identity)},
+			err:  ErrInvalidMetadata,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := testParser(protoconfyaml.Parser(), test.opts...).Unmarshal(test.data)
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestSOPS_Unmarshal_MACMismatch(t *testing.T) {
+	t.Parallel()
+
+	identity := newIdentity(t)
+	data := encrypt(t, plaintext, identity.Recipient())
+
+	// a plaintext value added to the encrypted file
+	tampered := bytes.Replace(data, []byte("limits:\n"), []byte("limits:\n    - 1\n"), 1)
+
+	_, err := testParser(protoconfyaml.Parser(), WithIdentities(identity)).Unmarshal(tampered)
+	require.ErrorIs(t, err, ErrMACMismatch)
+
+	values, err := testParser(protoconfyaml.Parser(), WithIdentities(identity), WithoutMACCheck()).Unmarshal(tampered)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 10, 2.5, true}, values["limits"])
+}
+
+// TestSOPS_Unmarshal_SOPSFiles decrypts the files encrypted by the sops CLI
+// with the throwaway key of testdata/key.txt:
+//
+//	sops encrypt --age <recipient> --output testdata/config.enc.yaml config.yaml
+func TestSOPS_Unmarshal_SOPSFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		file   string
+		parser protoconf.Parser
+		port   interface{}
+		limit  interface{}
+	}{
+		{
+			name:   "yaml",
+			file:   "testdata/config.enc.yaml",
+			parser: protoconfyaml.Parser(),
+			port:   443,
+			limit:  10,
+		},
+		{
+			name:   "json",
+			file:   "testdata/config.enc.json",
+			parser: json.Parser(),
+			port:   443.0,
+			limit:  10.0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := os.ReadFile(test.file)
+			require.NoError(t, err)
+
+			values, err := testParser(test.parser, WithKeyFile("testdata/key.txt")).Unmarshal(data)
+			require.NoError(t, err)
+
+			assert.Equal(t, map[string]interface{}{
+				"server": map[string]interface{}{
+					"http": map[string]interface{}{"addr": "0.0.0.0:80"},
+				},
+				"data": map[string]interface{}{
+					"database": map[string]interface{}{
+						"driver": "mysql",
+						"source": "root:root@tcp(127.0.0.1:3306)/test",
+					},
+					"redis": map[string]interface{}{
+						"addr":         "127.0.0.1:6379",
+						"read_timeout": "0.2s",
+					},
+				},
+				"limits": []interface{}{test.limit, 2.5, true, false},
+				"listeners": []interface{}{
+					map[string]interface{}{"name": "public", "port": test.port},
+				},
+			}, values)
+		})
+	}
+}
+
+func TestSOPS_Load(t *testing.T) {
+	t.Parallel()
+
+	identity := newIdentity(t)
+
+	path := filepath.Join(t.TempDir(), "config.enc.yaml")
+	require.NoError(t, os.WriteFile(path, encrypt(t, plaintext, identity.Recipient()), 0o600))
+
+	loader, err := protoconf.New(
+		protoconf.WithProvider(file.Provider(path)),
+		protoconf.WithParser(testParser(protoconfyaml.Parser(), WithIdentities(identity))),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.Config
+	require.NoError(t, loader.Scan(&cfg))
+
+	assert.Equal(t, "root:root@tcp(127.0.0.1:3306)/test", cfg.GetData().GetDatabase().GetSource())
+
+	origin, ok := loader.Explain("data.database.source")
+	require.True(t, ok)
+	assert.Equal(t, protoconf.Position{Line: 7, Column: 17}, origin.Position)
+}
+
+// testParser is Parser reading neither the environment nor the default
+// key file, the options can override the environment.
+func testParser(parser protoconf.Parser, opts ...Option) *SOPS {
+	return Parser(parser, append([]Option{WithoutDefaultKeyFile(), WithLookupEnv(lookupEnv(nil))}, opts...)...)
+}
+
+func newIdentity(t *testing.T) *age.X25519Identity {
+	t.Helper()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	return identity
+}
+
+func lookupEnv(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+
+		return value, ok
+	}
+}
+
+// encrypt encrypts the YAML document like `sops --encrypt --age`.
+func encrypt(t *testing.T, document string, recipient age.Recipient) []byte {
+	t.Helper()
+
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(document), &doc))
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	hash := sha512.New()
+	encryptNode(t, doc.Content[0], nil, key, func(value string) {
+		hash.Write([]byte(value))
+	})
+
+	var enc bytes.Buffer
+
+	armored := armor.NewWriter(&enc)
+	w, err := age.Encrypt(armored, recipient)
+	require.NoError(t, err)
+	_, err = w.Write(key)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, armored.Close())
+
+	lastModified := "2024-01-01T00:00:00Z"
+	metadata := map[string]interface{}{
+		"age": []interface{}{
+			map[string]interface{}{"recipient": fmt.Sprint(recipient), "enc": enc.String()},
+		},
+		"lastmodified": lastModified,
+		"mac":          encryptValue(t, fmt.Sprintf("%X", hash.Sum(nil)), "str", key, lastModified),
+		"version":      "3.8.1",
+	}
+
+	var metadataNode yaml.Node
+	require.NoError(t, metadataNode.Encode(metadata))
+
+	doc.Content[0].Content = append(doc.Content[0].Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "sops"}, &metadataNode)
+
+	data, err := yaml.Marshal(&doc)
+	require.NoError(t, err)
+
+	return data
+}
+
+func encryptNode(t *testing.T, node *yaml.Node, path []string, key []byte, hash func(string)) {
+	t.Helper()
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			encryptNode(t, node.Content[i+1], append(path[:len(path):len(path)], node.Content[i].Value), key, hash)
+		}
+	case yaml.SequenceNode:
+		for _, elem := range node.Content {
+			encryptNode(t, elem, path, key, hash)
+		}
+	case yaml.ScalarNode:
+		value, valueType := node.Value, "str"
+
+		switch node.Tag {
+		case "!!int":
+			valueType = "int"
+		case "!!float":
+			valueType = "float"
+		case "!!bool":
+			valueType = "bool"
+
+			parsed, err := strconv.ParseBool(value)
+			require.NoError(t, err)
+
+			// sops encrypts the booleans in the Python style
+			value = map[bool]string{true: "True", false: "False"}[parsed]
+		}
+
+		hash(value)
+
+		node.Value = encryptValue(t, value, valueType, key, strings.Join(path, ":")+":")
+		node.Tag = "!!str"
+		node.Style = 0
+	default:
+		t.Fatalf("unexpected node kind %v", node.Kind)
+	}
+}
+
+func encryptValue(t *testing.T, value, valueType string, key []byte, additionalData string) string {
+	t.Helper()
+
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+
+	gcm, err := cipher.NewGCMWithNonceSize(block, 32)
+	require.NoError(t, err)
+
+	iv := make([]byte, 32)
+	_, err = rand.Read(iv)
+	require.NoError(t, err)
+
+	sealed := gcm.Seal(nil, iv, []byte(value), []byte(additionalData))
+	data, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+
+	return fmt.Sprintf(
+		"ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:%s]",
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(iv),
+		base64.StdEncoding.EncodeToString(tag),
+		valueType,
+	)
+}
+
+// swapValues swaps the encrypted values of the keys.
+func swapValues(t *testing.T, data []byte, a, b string) []byte {
+	t.Helper()
+
+	lines := strings.Split(string(data), "\n")
+
+	var indexes []int
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, a+": ENC[") || strings.HasPrefix(trimmed, b+": ENC[") {
+			indexes = append(indexes, i)
+		}
+	}
+
+	require.GreaterOrEqual(t, len(indexes), 2)
+
+	first, second := lines[indexes[0]], lines[indexes[1]]
+	lines[indexes[0]] = first[:strings.Index(first, ":")+1] + second[strings.Index(second, ":")+1:]
+	lines[indexes[1]] = second[:strings.Index(second, ":")+1] + first[strings.Index(first, ":")+1:]
+
+	return []byte(strings.Join(lines, "\n"))
+}
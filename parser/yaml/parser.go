@@ -0,0 +1,230 @@
+// Package yaml provides a YAML parser reporting the positions of the values.
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gosynergy/protoconf"
+)
+
+var (
+	ErrNotMapping = errors.New("document is not a mapping")
+	ErrInclude    = errors.New("invalid !include")
+)
+
+// includeTag is the tag of an include directive, e.g.
+// `redis: !include ./common/redis.yaml`.
+const includeTag = "!include"
+
+// YAML is a YAML parser compatible with koanf yaml.Parser, which also
+// reports the positions of the values.
+type YAML struct{}
+
+var _ protoconf.PositionParser = (*YAML)(nil)
+
+// Parser creates a new YAML parser.
+func Parser() *YAML {
+	return &YAML{}
+}
+
+// Unmarshal parses the YAML document.
+func (p *YAML) Unmarshal(data []byte) (map[string]interface{}, error) {
+	values, _, err := p.UnmarshalWithPositions(data)
+
+	return values, err
+}
+
+// UnmarshalWithPositions parses the YAML document and returns the positions
+// of the values by their dotted path. The path of a list element is
+// the list path followed by the element index, e.g. `listeners[0].name`.
+//
+// An `!include` tagged path or list of paths is decoded as
+// the protoconf.IncludeKey directive, see protoconf.WithIncludes.
+func (p *YAML) UnmarshalWithPositions(data []byte) (map[string]interface{}, map[string]protoconf.Position, error) {
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("yaml unmarshal: %w", err)
+	}
+
+	dec := decoder{
+		positions: make(map[string]protoconf.Position),
+	}
+
+	if len(doc.Content) == 0 {
+		return map[string]interface{}{}, dec.positions, nil
+	}
+
+	value, err := dec.decode(doc.Content[0], "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if value == nil {
+		return map[string]interface{}{}, dec.positions, nil
+	}
+
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, nil, ErrNotMapping
+	}
+
+	return values, dec.positions, nil
+}
+
+type decoder struct {
+	positions map[string]protoconf.Position
+}
+
+func (d *decoder) decode(node *yaml.Node, path string) (interface{}, error) {
+	if node.Tag == includeTag {
+		return decodeInclude(node)
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		return d.decode(node.Content[0], path)
+	case yaml.AliasNode:
+		return d.decode(node.Alias, path)
+	case yaml.MappingNode:
+		return d.decodeMapping(node, path)
+	case yaml.SequenceNode:
+		list := make([]interface{}, 0, len(node.Content))
+
+		for i, elem := range node.Content {
+			elemPath := path + "[" + strconv.Itoa(i) + "]"
+			d.record(elemPath, elem)
+
+			value, err := d.decode(elem, elemPath)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, value)
+		}
+
+		return list, nil
+	case yaml.ScalarNode:
+	}
+
+	var value interface{}
+
+	err := node.Decode(&value)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", node.Line, err)
+	}
+
+	return value, nil
+}
+
+func (d *decoder) decodeMapping(node *yaml.Node, path string) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(node.Content)/2)
+
+	var merged []map[string]interface{}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+
+		if keyNode.Tag == "!!merge" {
+			maps, err := d.decodeMerge(valueNode, path)
+			if err != nil {
+				return nil, err
+			}
+
+			merged = append(merged, maps...)
+
+			continue
+		}
+
+		keyPath := joinPath(path, keyNode.Value)
+		d.record(keyPath, valueNode)
+
+		value, err := d.decode(valueNode, keyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		values[keyNode.Value] = value
+	}
+
+	// the explicit keys take precedence over the merged ones
+	for _, m := range merged {
+		for key, value := range m {
+			_, ok := values[key]
+			if !ok {
+				values[key] = value
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// decodeMerge decodes the value of a `<<` merge key.
+func (d *decoder) decodeMerge(node *yaml.Node, path string) ([]map[string]interface{}, error) {
+	value, err := d.decode(node, path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{v}, nil
+	case []interface{}:
+		maps := make([]map[string]interface{}, 0, len(v))
+
+		for _, elem := range v {
+			m, ok := elem.(map[string]interface{})
+			if ok {
+				maps = append(maps, m)
+			}
+		}
+
+		return maps, nil
+	default:
+		return nil, fmt.Errorf("line %d: %w", node.Line, ErrNotMapping)
+	}
+}
+
+// decodeInclude decodes an `!include` tagged path or list of paths as
+// the protoconf.IncludeKey directive, resolved by the loader.
+func decodeInclude(node *yaml.Node) (map[string]interface{}, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return map[string]interface{}{protoconf.IncludeKey: node.Value}, nil
+	case yaml.SequenceNode:
+		paths := make([]interface{}, 0, len(node.Content))
+
+		for _, elem := range node.Content {
+			if elem.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: %w: not a path", elem.Line, ErrInclude)
+			}
+
+			paths = append(paths, elem.Value)
+		}
+
+		return map[string]interface{}{protoconf.IncludeKey: paths}, nil
+	default:
+		return nil, fmt.Errorf("line %d: %w: not a path", node.Line, ErrInclude)
+	}
+}
+
+func (d *decoder) record(path string, node *yaml.Node) {
+	d.positions[path] = protoconf.Position{
+		Line:   node.Line,
+		Column: node.Column,
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
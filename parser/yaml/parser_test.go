@@ -0,0 +1,124 @@
+package yaml
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gosynergy/protoconf"
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+	"github.com/gosynergy/protoconf/provider/file"
+)
+
+func TestYAML_UnmarshalWithPositions(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`defaults: &defaults
+  timeout: 1s
+server:
+  <<: *defaults
+  addr: :8080
+  hosts:
+    - a
+    - b
+  enabled: true
+  ratio: 0.5
+`)
+
+	values, positions, err := Parser().UnmarshalWithPositions(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"defaults": map[string]interface{}{"timeout": "1s"},
+		"server": map[string]interface{}{
+			"timeout": "1s",
+			"addr":    ":8080",
+			"hosts":   []interface{}{"a", "b"},
+			"enabled": true,
+			"ratio":   0.5,
+		},
+	}, values)
+
+	assert.Equal(t, protoconf.Position{Line: 5, Column: 9}, positions["server.addr"])
+	assert.Equal(t, protoconf.Position{Line: 8, Column: 7}, positions["server.hosts[1]"])
+	assert.Equal(t, protoconf.Position{Line: 2, Column: 12}, positions["defaults.timeout"])
+}
+
+func TestYAML_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	values, err := Parser().Unmarshal(nil)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	_, err = Parser().Unmarshal([]byte("- a"))
+	require.ErrorIs(t, err, ErrNotMapping)
+
+	_, err = Parser().Unmarshal([]byte("a: ["))
+	require.Error(t, err)
+}
+
+func TestYAML_ValidationErrorPosition(t *testing.T) {
+	t.Parallel()
+
+	loader, err := protoconf.New(
+		protoconf.WithProvider(file.Provider("../../conf/invalid-config.yaml")),
+		protoconf.WithParser(Parser()),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.ConfigWithValidate
+	err = loader.Scan(&cfg)
+	require.Error(t, err)
+
+	var configErr *protoconf.ConfigError
+
+	require.True(t, errors.As(err, &configErr))
+	assert.Equal(t, "../../conf/invalid-config.yaml:3:11: server.http.addr: value is required [required]",
+		configErr.Error())
+}
+
+func TestYAML_Unmarshal_Include(t *testing.T) {
+	t.Parallel()
+
+	values, err := Parser().Unmarshal([]byte(`data:
+  redis: !include ./common/redis.yaml
+  database: !include [./common/database.yaml, ./secrets/database.yaml]
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"data": map[string]interface{}{
+			"redis": map[string]interface{}{
+				protoconf.IncludeKey: "./common/redis.yaml",
+			},
+			"database": map[string]interface{}{
+				protoconf.IncludeKey: []interface{}{"./common/database.yaml", "./secrets/database.yaml"},
+			},
+		},
+	}, values)
+
+	_, err = Parser().Unmarshal([]byte("redis: !include {path: a.yaml}\n"))
+	require.ErrorIs(t, err, ErrInclude)
+}
+
+func TestYAML_Include_Load(t *testing.T) {
+	t.Parallel()
+
+	loader, err := protoconf.New(
+		protoconf.WithProvider(file.Provider("../../conf/include/tagged.yaml")),
+		protoconf.WithParser(Parser()),
+		protoconf.WithIncludes(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.Config
+	require.NoError(t, loader.Scan(&cfg))
+
+	assert.Equal(t, "127.0.0.1:6379", cfg.GetData().GetRedis().GetAddr())
+	assert.Equal(t, "tcp", cfg.GetData().GetRedis().GetNetwork())
+}
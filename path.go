@@ -0,0 +1,36 @@
+package protoconf
+
+import (
+	"sort"
+)
+
+// joinPath appends the key to the dotted path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedOriginPaths(origins map[string]Origin) []string {
+	paths := make([]string, 0, len(origins))
+	for path := range origins {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
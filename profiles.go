@@ -0,0 +1,189 @@
+package protoconf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// profilesKey is the key of the profiles section of a configuration.
+const profilesKey = "profiles"
+
+var ErrNoParser = errors.New("no parser")
+
+// Profiles returns the active profiles, see WithProfiles.
+func (c *ConfigLoader) Profiles() []string {
+	return append([]string(nil), c.opts.profiles...)
+}
+
+// readLayer reads the layer and the profiles of the layer, which are merged
+// in order over the layer values. The read errors are collected, see
+// WithCollectErrors.
+func (c *ConfigLoader) readLayer(ctx context.Context, config *loaded, l layer) error {
+	src, err := c.readSource(ctx, l)
+	if err != nil {
+		return c.collectSourceError(config, KindSource, l.provider, err)
+	}
+
+	sections := c.profileSections(src)
+
+	err = c.mergeLayer(config, src, providerOrigin(l.provider))
+	if err != nil {
+		return err
+	}
+
+	for _, profile := range c.opts.profiles {
+		section, ok := sections[profile]
+		if ok {
+			origin := providerOrigin(l.provider)
+			origin.Profile = profile
+
+			err = c.mergeLayer(config, section, origin)
+			if err != nil {
+				return fmt.Errorf("profile %s: %w", profile, err)
+			}
+		}
+
+		err = c.readProfileFile(ctx, config, l, profile)
+		if err != nil {
+			return fmt.Errorf("profile %s: %w", profile, err)
+		}
+	}
+
+	return nil
+}
+
+// readProfileFile reads the `<name>.<profile>.<ext>` sibling of the layer
+// file, if it exists.
+func (c *ConfigLoader) readProfileFile(ctx context.Context, config *loaded, l layer, profile string) error {
+	fileProvider, ok := l.provider.(FileProvider)
+	if !ok {
+		return nil
+	}
+
+	path := profilePath(fileProvider.Path(), profile)
+
+	_, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	provider := &localFile{path: path}
+
+	src, err := c.readSource(ctx, layer{provider: provider, parser: l.parser})
+	if err != nil {
+		return c.collectSourceError(config, KindSource, provider, err)
+	}
+
+	// the sections of a profile file are not applied
+	c.profileSections(src)
+
+	origin := providerOrigin(provider)
+	origin.Profile = profile
+
+	return c.mergeLayer(config, src, origin)
+}
+
+// mergeLayer merges the source values and records their origins.
+func (c *ConfigLoader) mergeLayer(config *loaded, src *source, origin Origin) error {
+	var err error
+
+	config.values, err = c.opts.merger.Merge(config.values, src.values)
+	if err != nil {
+		return fmt.Errorf("merge: %w", err)
+	}
+
+	recordOrigins(config.origins, src.values, origin, src.positions)
+
+	for path, includedOrigin := range src.origins {
+		includedOrigin.Profile = origin.Profile
+		config.origins[path] = includedOrigin
+	}
+
+	return nil
+}
+
+// profileSections removes the `profiles` section from the source values and
+// returns the sources of its profiles. The section is kept if WithProfiles
+// is not set.
+func (c *ConfigLoader) profileSections(src *source) map[string]*source {
+	if c.opts.profiles == nil {
+		return nil
+	}
+
+	profiles, ok := src.values[profilesKey].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	delete(src.values, profilesKey)
+
+	sections := make(map[string]*source, len(profiles))
+
+	for profile, value := range profiles {
+		sectionValues, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		prefix := profilesKey + "." + profile + "."
+		section := &source{
+			values:    sectionValues,
+			positions: make(map[string]Position),
+			origins:   make(map[string]Origin),
+		}
+
+		for path, position := range src.positions {
+			if strings.HasPrefix(path, prefix) {
+				section.positions[strings.TrimPrefix(path, prefix)] = position
+			}
+		}
+
+		for path, origin := range src.origins {
+			if strings.HasPrefix(path, prefix) {
+				section.origins[strings.TrimPrefix(path, prefix)] = origin
+			}
+		}
+
+		sections[profile] = section
+	}
+
+	return sections
+}
+
+// profilePath returns the path of the profile file, e.g.
+// `conf/config.prod.yaml` for `conf/config.yaml`.
+func profilePath(path, profile string) string {
+	ext := filepath.Ext(path)
+
+	return strings.TrimSuffix(path, ext) + "." + profile + ext
+}
+
+// localFile is the provider of a profile or an included file.
+type localFile struct {
+	path string
+}
+
+func (f *localFile) ReadBytes() ([]byte, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	return data, nil
+}
+
+func (f *localFile) Read() (map[string]interface{}, error) {
+	return nil, fmt.Errorf("%s: %w", f.path, ErrNoParser)
+}
+
+func (f *localFile) Path() string {
+	return f.path
+}
+
+func (f *localFile) Name() string {
+	return "file"
+}
@@ -0,0 +1,108 @@
+package protoconf
+
+import (
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+	"github.com/gosynergy/protoconf/provider/file"
+)
+
+func TestConfigLoader_Profiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		profiles []string
+		addr     string
+		source   string
+	}{
+		{
+			name:   "no profile",
+			addr:   "127.0.0.1:8080",
+			source: "",
+		},
+		{
+			name:     "section and file",
+			profiles: []string{"prod"},
+			addr:     "0.0.0.0:80",
+			source:   "prod.db",
+		},
+		{
+			name:     "in order",
+			profiles: []string{"prod", "eu-west"},
+			addr:     "0.0.0.0:80",
+			source:   "eu-west.db",
+		},
+		{
+			name:     "reverse order",
+			profiles: []string{"eu-west", "prod"},
+			addr:     "0.0.0.0:80",
+			source:   "prod.db",
+		},
+		{
+			name:     "unknown profile",
+			profiles: []string{"staging"},
+			addr:     "127.0.0.1:8080",
+			source:   "",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			loader, err := New(
+				WithProvider(file.Provider("conf/config-profiles.yaml")),
+				WithParser(yaml.Parser()),
+				WithProfiles(test.profiles...),
+				WithStrict(),
+			)
+			require.NoError(t, err)
+			require.NoError(t, loader.Load())
+
+			var cfg v1.Config
+			require.NoError(t, loader.Scan(&cfg))
+
+			assert.Equal(t, test.addr, cfg.GetServer().GetHttp().GetAddr())
+			assert.Equal(t, test.source, cfg.GetData().GetDatabase().GetSource())
+			assert.Equal(t, test.profiles, loader.Profiles())
+		})
+	}
+}
+
+func TestConfigLoader_Profiles_Explain(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithLayer(file.Provider("conf/config-profiles.yaml"), linePositionParser{yaml.Parser()}),
+		WithProfiles("prod"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	origin, ok := loader.Explain("server.http.addr")
+	require.True(t, ok)
+	assert.Equal(t, "conf/config-profiles.yaml:11:9 (file, profile prod)", origin.String())
+
+	origin, ok = loader.Explain("data.database.source")
+	require.True(t, ok)
+	assert.Equal(t, "conf/config-profiles.prod.yaml:3:5 (file, profile prod)", origin.String())
+
+	origin, ok = loader.Explain("data.database.driver")
+	require.True(t, ok)
+	assert.Equal(t, "conf/config-profiles.yaml:6:5 (file)", origin.String())
+}
+
+func TestProfilePath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "conf/config.prod.yaml", profilePath("conf/config.yaml", "prod"))
+	assert.Equal(t, "conf/config.d/app.eu-west.json", profilePath("conf/config.d/app.json", "eu-west"))
+	assert.Equal(t, "config.prod", profilePath("config", "prod"))
+}
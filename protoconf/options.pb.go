@@ -0,0 +1,537 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        (unknown)
+// source: protoconf/options.proto
+
+package protoconfpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// MapStrategy is the merge strategy for map and message values.
+type MapStrategy int32
+
+const (
+	// MAP_STRATEGY_UNSPECIFIED falls back to the merger default.
+	MapStrategy_MAP_STRATEGY_UNSPECIFIED MapStrategy = 0
+	// MAP_STRATEGY_MERGE merges the maps recursively.
+	MapStrategy_MAP_STRATEGY_MERGE MapStrategy = 1
+	// MAP_STRATEGY_REPLACE replaces the whole map.
+	MapStrategy_MAP_STRATEGY_REPLACE MapStrategy = 2
+)
+
+// Enum value maps for MapStrategy.
+var (
+	MapStrategy_name = map[int32]string{
+		0: "MAP_STRATEGY_UNSPECIFIED",
+		1: "MAP_STRATEGY_MERGE",
+		2: "MAP_STRATEGY_REPLACE",
+	}
+	MapStrategy_value = map[string]int32{
+		"MAP_STRATEGY_UNSPECIFIED": 0,
+		"MAP_STRATEGY_MERGE":       1,
+		"MAP_STRATEGY_REPLACE":     2,
+	}
+)
+
+func (x MapStrategy) Enum() *MapStrategy {
+	p := new(MapStrategy)
+	*p = x
+	return p
+}
+
+func (x MapStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MapStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_protoconf_options_proto_enumTypes[0].Descriptor()
+}
+
+func (MapStrategy) Type() protoreflect.EnumType {
+	return &file_protoconf_options_proto_enumTypes[0]
+}
+
+func (x MapStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MapStrategy.Descriptor instead.
+func (MapStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_protoconf_options_proto_rawDescGZIP(), []int{0}
+}
+
+// ListStrategy is the merge strategy for repeated values.
+type ListStrategy int32
+
+const (
+	// LIST_STRATEGY_UNSPECIFIED falls back to the merger default.
+	ListStrategy_LIST_STRATEGY_UNSPECIFIED ListStrategy = 0
+	// LIST_STRATEGY_REPLACE replaces the whole list.
+	ListStrategy_LIST_STRATEGY_REPLACE ListStrategy = 1
+	// LIST_STRATEGY_APPEND appends the elements to the list.
+	ListStrategy_LIST_STRATEGY_APPEND ListStrategy = 2
+	// LIST_STRATEGY_MERGE_BY_KEY merges the elements having the same key
+	// and appends the others.
+	ListStrategy_LIST_STRATEGY_MERGE_BY_KEY ListStrategy = 3
+)
+
+// Enum value maps for ListStrategy.
+var (
+	ListStrategy_name = map[int32]string{
+		0: "LIST_STRATEGY_UNSPECIFIED",
+		1: "LIST_STRATEGY_REPLACE",
+		2: "LIST_STRATEGY_APPEND",
+		3: "LIST_STRATEGY_MERGE_BY_KEY",
+	}
+	ListStrategy_value = map[string]int32{
+		"LIST_STRATEGY_UNSPECIFIED":  0,
+		"LIST_STRATEGY_REPLACE":      1,
+		"LIST_STRATEGY_APPEND":       2,
+		"LIST_STRATEGY_MERGE_BY_KEY": 3,
+	}
+)
+
+func (x ListStrategy) Enum() *ListStrategy {
+	p := new(ListStrategy)
+	*p = x
+	return p
+}
+
+func (x ListStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_protoconf_options_proto_enumTypes[1].Descriptor()
+}
+
+func (ListStrategy) Type() protoreflect.EnumType {
+	return &file_protoconf_options_proto_enumTypes[1]
+}
+
+func (x ListStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListStrategy.Descriptor instead.
+func (ListStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_protoconf_options_proto_rawDescGZIP(), []int{1}
+}
+
+// ConfigOptions marks a message as a configuration message. The
+// protoc-gen-protoconf plugin generates the loader helpers of the
+// configuration messages.
+type ConfigOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// env_prefix is the prefix of the environment variables bound to
+	// the fields, e.g. `APP` for `APP_SERVER_HTTP_ADDR`.
+	EnvPrefix string `protobuf:"bytes,1,opt,name=env_prefix,json=envPrefix,proto3" json:"env_prefix,omitempty"`
+	// flag_prefix is the prefix of the command-line flags bound to
+	// the fields, e.g. `config` for `--config.server.http.addr`.
+	FlagPrefix string `protobuf:"bytes,2,opt,name=flag_prefix,json=flagPrefix,proto3" json:"flag_prefix,omitempty"`
+}
+
+func (x *ConfigOptions) Reset() {
+	*x = ConfigOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoconf_options_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigOptions) ProtoMessage() {}
+
+func (x *ConfigOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_protoconf_options_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigOptions.ProtoReflect.Descriptor instead.
+func (*ConfigOptions) Descriptor() ([]byte, []int) {
+	return file_protoconf_options_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConfigOptions) GetEnvPrefix() string {
+	if x != nil {
+		return x.EnvPrefix
+	}
+	return ""
+}
+
+func (x *ConfigOptions) GetFlagPrefix() string {
+	if x != nil {
+		return x.FlagPrefix
+	}
+	return ""
+}
+
+// FieldOptions are the protoconf options of a configuration field.
+type FieldOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// merge controls how the values of several layers are merged for the field.
+	Merge *MergeOptions `protobuf:"bytes,1,opt,name=merge,proto3" json:"merge,omitempty"`
+	// default is the value of the field when it is not set. It is the JSON
+	// representation of the value, e.g. `"1s"` for a Duration, `true` for
+	// a bool or `{"addr": ":8080"}` for a message. Strings, enums and other
+	// values represented as JSON strings may be written without quotes.
+	Default string `protobuf:"bytes,2,opt,name=default,proto3" json:"default,omitempty"`
+	// env is the name of the environment variable bound to the field. It
+	// overrides the name derived from the field path.
+	Env string `protobuf:"bytes,3,opt,name=env,proto3" json:"env,omitempty"`
+	// sensitive marks the field as holding a secret, e.g. a password or a DSN
+	// with credentials. Its value is masked by protoconf.Redact.
+	Sensitive bool `protobuf:"varint,4,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+}
+
+func (x *FieldOptions) Reset() {
+	*x = FieldOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoconf_options_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldOptions) ProtoMessage() {}
+
+func (x *FieldOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_protoconf_options_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldOptions.ProtoReflect.Descriptor instead.
+func (*FieldOptions) Descriptor() ([]byte, []int) {
+	return file_protoconf_options_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FieldOptions) GetMerge() *MergeOptions {
+	if x != nil {
+		return x.Merge
+	}
+	return nil
+}
+
+func (x *FieldOptions) GetDefault() string {
+	if x != nil {
+		return x.Default
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetEnv() string {
+	if x != nil {
+		return x.Env
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
+}
+
+// MergeOptions controls how the values of several layers are merged.
+type MergeOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// map is the strategy for map and message values.
+	Map MapStrategy `protobuf:"varint,1,opt,name=map,proto3,enum=protoconf.MapStrategy" json:"map,omitempty"`
+	// list is the strategy for repeated values.
+	List ListStrategy `protobuf:"varint,2,opt,name=list,proto3,enum=protoconf.ListStrategy" json:"list,omitempty"`
+	// key is the name of the element field used by LIST_STRATEGY_MERGE_BY_KEY.
+	Key string `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *MergeOptions) Reset() {
+	*x = MergeOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protoconf_options_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergeOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeOptions) ProtoMessage() {}
+
+func (x *MergeOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_protoconf_options_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeOptions.ProtoReflect.Descriptor instead.
+func (*MergeOptions) Descriptor() ([]byte, []int) {
+	return file_protoconf_options_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MergeOptions) GetMap() MapStrategy {
+	if x != nil {
+		return x.Map
+	}
+	return MapStrategy_MAP_STRATEGY_UNSPECIFIED
+}
+
+func (x *MergeOptions) GetList() ListStrategy {
+	if x != nil {
+		return x.List
+	}
+	return ListStrategy_LIST_STRATEGY_UNSPECIFIED
+}
+
+func (x *MergeOptions) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+var file_protoconf_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldOptions)(nil),
+		Field:         51200,
+		Name:          "protoconf.field",
+		Tag:           "bytes,51200,opt,name=field",
+		Filename:      "protoconf/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*ConfigOptions)(nil),
+		Field:         51200,
+		Name:          "protoconf.config",
+		Tag:           "bytes,51200,opt,name=config",
+		Filename:      "protoconf/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional protoconf.FieldOptions field = 51200;
+	E_Field = &file_protoconf_options_proto_extTypes[0]
+)
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// optional protoconf.ConfigOptions config = 51200;
+	E_Config = &file_protoconf_options_proto_extTypes[1]
+)
+
+var File_protoconf_options_proto protoreflect.FileDescriptor
+
+var file_protoconf_options_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6e, 0x66, 0x2f, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6e, 0x66, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4f, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x76, 0x5f, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x6e, 0x76,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6c, 0x61, 0x67, 0x5f, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x6c, 0x61,
+	0x67, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x87, 0x01, 0x0a, 0x0c, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x05, 0x6d, 0x65, 0x72, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6e, 0x66, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x05, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x65, 0x6e, 0x76, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76,
+	0x65, 0x22, 0x77, 0x0a, 0x0c, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x28, 0x0a, 0x03, 0x6d, 0x61, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x4d, 0x61, 0x70, 0x53, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x52, 0x03, 0x6d, 0x61, 0x70, 0x12, 0x2b, 0x0a, 0x04, 0x6c,
+	0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x67, 0x79, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x2a, 0x5d, 0x0a, 0x0b, 0x4d, 0x61,
+	0x70, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x41, 0x50,
+	0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x4d, 0x41, 0x50, 0x5f, 0x53,
+	0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x10, 0x01, 0x12,
+	0x18, 0x0a, 0x14, 0x4d, 0x41, 0x50, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f,
+	0x52, 0x45, 0x50, 0x4c, 0x41, 0x43, 0x45, 0x10, 0x02, 0x2a, 0x82, 0x01, 0x0a, 0x0c, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x1d, 0x0a, 0x19, 0x4c, 0x49,
+	0x53, 0x54, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x4c, 0x49, 0x53,
+	0x54, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x52, 0x45, 0x50, 0x4c, 0x41,
+	0x43, 0x45, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x53, 0x54, 0x52,
+	0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x41, 0x50, 0x50, 0x45, 0x4e, 0x44, 0x10, 0x02, 0x12, 0x1e,
+	0x0a, 0x1a, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f,
+	0x4d, 0x45, 0x52, 0x47, 0x45, 0x5f, 0x42, 0x59, 0x5f, 0x4b, 0x45, 0x59, 0x10, 0x03, 0x3a, 0x4e,
+	0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x80, 0x90, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x3a, 0x53,
+	0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x80, 0x90, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6e, 0x66, 0x2e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x67, 0x6f, 0x73, 0x79, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6e, 0x66, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6e, 0x66, 0x3b,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6e, 0x66, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protoconf_options_proto_rawDescOnce sync.Once
+	file_protoconf_options_proto_rawDescData = file_protoconf_options_proto_rawDesc
+)
+
+func file_protoconf_options_proto_rawDescGZIP() []byte {
+	file_protoconf_options_proto_rawDescOnce.Do(func() {
+		file_protoconf_options_proto_rawDescData = protoimpl.X.CompressGZIP(file_protoconf_options_proto_rawDescData)
+	})
+	return file_protoconf_options_proto_rawDescData
+}
+
+var file_protoconf_options_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_protoconf_options_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_protoconf_options_proto_goTypes = []interface{}{
+	(MapStrategy)(0),                    // 0: protoconf.MapStrategy
+	(ListStrategy)(0),                   // 1: protoconf.ListStrategy
+	(*ConfigOptions)(nil),               // 2: protoconf.ConfigOptions
+	(*FieldOptions)(nil),                // 3: protoconf.FieldOptions
+	(*MergeOptions)(nil),                // 4: protoconf.MergeOptions
+	(*descriptorpb.FieldOptions)(nil),   // 5: google.protobuf.FieldOptions
+	(*descriptorpb.MessageOptions)(nil), // 6: google.protobuf.MessageOptions
+}
+var file_protoconf_options_proto_depIdxs = []int32{
+	4, // 0: protoconf.FieldOptions.merge:type_name -> protoconf.MergeOptions
+	0, // 1: protoconf.MergeOptions.map:type_name -> protoconf.MapStrategy
+	1, // 2: protoconf.MergeOptions.list:type_name -> protoconf.ListStrategy
+	5, // 3: protoconf.field:extendee -> google.protobuf.FieldOptions
+	6, // 4: protoconf.config:extendee -> google.protobuf.MessageOptions
+	3, // 5: protoconf.field:type_name -> protoconf.FieldOptions
+	2, // 6: protoconf.config:type_name -> protoconf.ConfigOptions
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	5, // [5:7] is the sub-list for extension type_name
+	3, // [3:5] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_protoconf_options_proto_init() }
+func file_protoconf_options_proto_init() {
+	if File_protoconf_options_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protoconf_options_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoconf_options_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FieldOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protoconf_options_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergeOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protoconf_options_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   3,
+			NumExtensions: 2,
+			NumServices:   0,
+		},
+		GoTypes:           file_protoconf_options_proto_goTypes,
+		DependencyIndexes: file_protoconf_options_proto_depIdxs,
+		EnumInfos:         file_protoconf_options_proto_enumTypes,
+		MessageInfos:      file_protoconf_options_proto_msgTypes,
+		ExtensionInfos:    file_protoconf_options_proto_extTypes,
+	}.Build()
+	File_protoconf_options_proto = out.File
+	file_protoconf_options_proto_rawDesc = nil
+	file_protoconf_options_proto_goTypes = nil
+	file_protoconf_options_proto_depIdxs = nil
+}
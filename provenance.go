@@ -0,0 +1,205 @@
+package protoconf
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Origin describes where a configuration value comes from.
+type Origin struct {
+	// Provider is the name of the provider, see Named.
+	Provider string
+	// File is the file the value is read from, if the provider
+	// implements FileProvider.
+	File string
+	// Position is the position of the value in the file, if the parser
+	// implements PositionParser.
+	Position Position
+	// Profile is the profile of the value, if it comes from a profile
+	// section or file, see WithProfiles.
+	Profile string
+	// Transformers are the names of the transformers which changed
+	// the value, in the order they were applied.
+	Transformers []string
+}
+
+// String returns the origin in the `file:line:column (provider)` form,
+// e.g. `conf/config.prod.yaml:3:5 (file, profile prod)`.
+func (o Origin) String() string {
+	var builder strings.Builder
+
+	if o.File != "" {
+		builder.WriteString(o.File)
+
+		if o.Position.Line > 0 {
+			builder.WriteString(":" + strconv.Itoa(o.Position.Line) + ":" + strconv.Itoa(o.Position.Column))
+		}
+
+		builder.WriteString(" ")
+	}
+
+	builder.WriteString("(" + o.Provider)
+
+	if o.Profile != "" {
+		builder.WriteString(", profile " + o.Profile)
+	}
+
+	builder.WriteString(")")
+
+	if len(o.Transformers) > 0 {
+		builder.WriteString(" transformed by " + strings.Join(o.Transformers, ", "))
+	}
+
+	return builder.String()
+}
+
+// Explain returns the origin of the leaf value at the dotted path, e.g.
+// `server.http.addr`. The origin of a list element is the origin
+// of the list. A message path, e.g. `server.http`, has no single origin,
+// see ExplainAll.
+func (c *ConfigLoader) Explain(path string) (Origin, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return explain(c.origins, path)
+}
+
+// ExplainAll returns the origins of the leaf values at or under the dotted
+// path by their path, e.g. the origins of `server.http.addr` and
+// `server.http.timeout` for `server.http`. An empty path returns
+// the origins of every value.
+func (c *ConfigLoader) ExplainAll(path string) map[string]Origin {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	origins := make(map[string]Origin)
+
+	origin, ok := explain(c.origins, path)
+	if ok {
+		origins[path] = origin
+
+		return origins
+	}
+
+	for leafPath, origin := range c.origins {
+		if path == "" || strings.HasPrefix(leafPath, path+".") || strings.HasPrefix(leafPath, path+"[") {
+			origins[leafPath] = origin
+		}
+	}
+
+	return origins
+}
+
+func explain(origins map[string]Origin, path string) (Origin, bool) {
+	for path != "" {
+		origin, ok := origins[path]
+		if ok {
+			return origin, true
+		}
+
+		idx := strings.LastIndexAny(path, ".[")
+		if idx < 0 {
+			break
+		}
+
+		path = path[:idx]
+	}
+
+	return Origin{}, false
+}
+
+// recordOrigins sets the origin of every leaf of the layer values.
+func recordOrigins(
+	origins map[string]Origin,
+	values map[string]interface{},
+	origin Origin,
+	positions map[string]Position,
+) {
+	for path := range flatten(values) {
+		leafOrigin := origin
+		leafOrigin.Position = positions[path]
+		origins[path] = leafOrigin
+	}
+}
+
+// pruneOrigins removes the origins of the values replaced by the next layers.
+func pruneOrigins(origins map[string]Origin, values map[string]interface{}) {
+	leaves := flatten(values)
+
+	for path := range origins {
+		_, ok := leaves[path]
+		if !ok {
+			delete(origins, path)
+		}
+	}
+}
+
+// traceTransform records the transformer in the origins of the changed leaves.
+func traceTransform(origins map[string]Origin, before, after map[string]interface{}, name string) {
+	for path, value := range after {
+		prev, ok := before[path]
+		if ok && reflect.DeepEqual(prev, value) {
+			continue
+		}
+
+		origin := origins[path]
+		origin.Transformers = append(append([]string(nil), origin.Transformers...), name)
+		origins[path] = origin
+	}
+
+	for path := range before {
+		_, ok := after[path]
+		if !ok {
+			delete(origins, path)
+		}
+	}
+}
+
+// flatten returns the leaves of the values by their dotted path. Lists and
+// empty maps are leaves.
+func flatten(values map[string]interface{}) map[string]interface{} {
+	leaves := make(map[string]interface{})
+	flattenInto(leaves, "", values)
+
+	return leaves
+}
+
+func flattenInto(leaves map[string]interface{}, path string, values map[string]interface{}) {
+	for key, value := range values {
+		keyPath := joinPath(path, key)
+
+		nested, ok := value.(map[string]interface{})
+		if ok && len(nested) > 0 {
+			flattenInto(leaves, keyPath, nested)
+
+			continue
+		}
+
+		leaves[keyPath] = value
+	}
+}
+
+func providerOrigin(p Provider) Origin {
+	origin := Origin{
+		Provider: name(p),
+	}
+
+	fileProvider, ok := p.(FileProvider)
+	if ok {
+		origin.File = fileProvider.Path()
+	}
+
+	return origin
+}
+
+// name returns the name of a provider or a transformer.
+func name(v interface{}) string {
+	named, ok := v.(Named)
+	if ok {
+		return named.Name()
+	}
+
+	return strings.TrimPrefix(fmt.Sprintf("%T", v), "*")
+}
@@ -0,0 +1,166 @@
+package protoconf
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+	"github.com/gosynergy/protoconf/provider/file"
+	"github.com/gosynergy/protoconf/transform/expandenv"
+)
+
+// linePositionParser reports the line of every top-level-indented key.
+type linePositionParser struct {
+	Parser
+}
+
+func (p linePositionParser) UnmarshalWithPositions(
+	data []byte,
+) (map[string]interface{}, map[string]Position, error) {
+	values, err := p.Unmarshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	positions := make(map[string]Position)
+
+	var path []string
+
+	for i, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		key, _, ok := strings.Cut(trimmed, ":")
+
+		if !ok || trimmed == "" {
+			continue
+		}
+
+		depth := (len(line) - len(trimmed)) / 2
+		path = append(path[:depth], key)
+		positions[strings.Join(path, ".")] = Position{Line: i + 1, Column: len(line) - len(trimmed) + 1}
+	}
+
+	return values, positions, nil
+}
+
+func TestConfigLoader_Explain(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithLayer(file.Provider("conf/config-env-expand.yaml"), linePositionParser{yaml.Parser()}),
+		WithLayer(file.Provider("conf/config-overlay.json"), yaml.Parser()),
+		WithTransformers(expandenv.NewTransformer(expandenv.WithGetenv(func(name string) string {
+			if name == "HTTP_ADDR" {
+				return "localhost:8080"
+			}
+
+			return ""
+		}))),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	origin, ok := loader.Explain("server.grpc.addr")
+	require.True(t, ok)
+	assert.Equal(t, Origin{
+		Provider:     "file",
+		File:         "conf/config-env-expand.yaml",
+		Position:     Position{Line: 6, Column: 5},
+		Transformers: []string{"expandenv"},
+	}, origin)
+	assert.Equal(t, "conf/config-env-expand.yaml:6:5 (file) transformed by expandenv", origin.String())
+
+	origin, ok = loader.Explain("server.http.addr")
+	require.True(t, ok)
+	assert.Equal(t, Origin{Provider: "file", File: "conf/config-overlay.json"}, origin)
+
+	origin, ok = loader.Explain("data.database.driver")
+	require.True(t, ok)
+	assert.Equal(t, "conf/config-env-expand.yaml:10:5 (file)", origin.String())
+
+	_, ok = loader.Explain("data.database")
+	assert.False(t, ok)
+
+	_, ok = loader.Explain("server.http.unknown")
+	assert.False(t, ok)
+
+	origins := loader.ExplainAll("data.database")
+	assert.Equal(t, map[string]string{
+		"data.database.driver": "conf/config-env-expand.yaml:10:5 (file)",
+		"data.database.source": "conf/config-env-expand.yaml:11:5 (file)",
+	}, originStrings(origins))
+
+	origins = loader.ExplainAll("server.http.addr")
+	assert.Equal(t, map[string]string{"server.http.addr": "conf/config-overlay.json (file)"}, originStrings(origins))
+
+	assert.Empty(t, loader.ExplainAll("server.http.unknown"))
+	assert.Empty(t, loader.ExplainAll("data.data"))
+	assert.Len(t, loader.ExplainAll(""), 9)
+}
+
+func originStrings(origins map[string]Origin) map[string]string {
+	strs := make(map[string]string, len(origins))
+	for path, origin := range origins {
+		strs[path] = origin.String()
+	}
+
+	return strs
+}
+
+func TestConfigLoader_ExplainListElement(t *testing.T) {
+	t.Parallel()
+
+	provider := NewMockProvider(t)
+	provider.
+		EXPECT().
+		Read().
+		Return(map[string]interface{}{
+			"listeners": []interface{}{
+				map[string]interface{}{"name": "http"},
+			},
+		}, nil)
+
+	loader, err := New(WithProvider(provider))
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	origin, ok := loader.Explain("listeners[0].name")
+	require.True(t, ok)
+	assert.Equal(t, "protoconf.MockProvider", origin.Provider)
+}
+
+func TestConfigLoader_ValidationErrorExplainsOrigin(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/invalid-config.yaml")),
+		WithParser(linePositionParser{yaml.Parser()}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.ConfigWithValidate
+	err = loader.Scan(&cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conf/invalid-config.yaml:3:5: server.http.addr: value is required [required]")
+}
+
+func TestConfigLoader_UnknownFieldErrorExplainsOrigin(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/unknown-key-config.yaml")),
+		WithParser(linePositionParser{yaml.Parser()}),
+		WithStrict(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.Config
+	err = loader.Scan(&cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"server.debug" from conf/unknown-key-config.yaml:8:3 (file)`)
+}
@@ -0,0 +1,43 @@
+package env
+
+// Option is provider option.
+type Option func(*options)
+
+type options struct {
+	prefix        string
+	separator     string
+	listSeparator string
+	lookupEnv     func(string) (string, bool)
+}
+
+// WithPrefix sets the prefix of the variable names, e.g. `APP` for
+// `APP_SERVER_HTTP_ADDR`.
+func WithPrefix(prefix string) Option {
+	return func(opts *options) {
+		opts.prefix = prefix
+	}
+}
+
+// WithSeparator sets the separator of the variable name parts.
+// The default is `_`.
+func WithSeparator(sep string) Option {
+	return func(opts *options) {
+		opts.separator = sep
+	}
+}
+
+// WithListSeparator sets the separator of the list elements and
+// the map entries. The default is `,`.
+func WithListSeparator(sep string) Option {
+	return func(opts *options) {
+		opts.listSeparator = sep
+	}
+}
+
+// WithLookupEnv sets the function used to look up the variables.
+// The default is os.LookupEnv.
+func WithLookupEnv(lookupEnv func(string) (string, bool)) Option {
+	return func(opts *options) {
+		opts.lookupEnv = lookupEnv
+	}
+}
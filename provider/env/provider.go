@@ -0,0 +1,132 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/gosynergy/protoconf/internal/convert"
+	"github.com/gosynergy/protoconf/internal/schema"
+)
+
+// Binding binds an environment variable to a configuration key.
+type Binding struct {
+	// Name is the environment variable name, e.g. `APP_SERVER_HTTP_ADDR`.
+	Name string
+	// Path is the dotted configuration key, e.g. `server.http.addr`.
+	Path string
+
+	leaf schema.Leaf
+}
+
+// Env reads the configuration from the environment variables bound to
+// the fields of a configuration message.
+//
+// The variable name of a field is derived from the field path, e.g.
+// `APP_SERVER_HTTP_ADDR` for `server.http.addr` with the `APP` prefix,
+// unless it is set by the `(protoconf.field).env` option. The values are
+// converted to the field types: lists are separated by commas, map
+// entries are key=value pairs separated by commas and durations use
+// the time.ParseDuration format.
+type Env struct {
+	opts     options
+	bindings []Binding
+}
+
+// Provider creates a new Env provider for the message.
+func Provider(desc protoreflect.MessageDescriptor, opts ...Option) *Env {
+	envOpts := options{
+		separator:     "_",
+		listSeparator: ",",
+	}
+
+	for _, opt := range opts {
+		opt(&envOpts)
+	}
+
+	if envOpts.lookupEnv == nil {
+		envOpts.lookupEnv = os.LookupEnv
+	}
+
+	leaves := schema.Leaves(desc)
+	bindings := make([]Binding, 0, len(leaves))
+
+	for _, leaf := range leaves {
+		bindings = append(bindings, Binding{
+			Name: envOpts.name(leaf),
+			Path: leaf.Path(),
+			leaf: leaf,
+		})
+	}
+
+	return &Env{
+		opts:     envOpts,
+		bindings: bindings,
+	}
+}
+
+// Bindings returns the bindings of the message fields.
+func (e *Env) Bindings() []Binding {
+	bindings := make([]Binding, len(e.bindings))
+	copy(bindings, e.bindings)
+
+	return bindings
+}
+
+// Name returns the provider name.
+func (e *Env) Name() string {
+	return "env"
+}
+
+// ReadBytes returns the configuration encoded as JSON.
+func (e *Env) ReadBytes() ([]byte, error) {
+	values, err := e.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("json marshal: %w", err)
+	}
+
+	return data, nil
+}
+
+// Read returns the values of the set environment variables.
+func (e *Env) Read() (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+
+	for _, binding := range e.bindings {
+		raw, ok := e.opts.lookupEnv(binding.Name)
+		if !ok {
+			continue
+		}
+
+		value, err := convert.Value(binding.leaf.Field(), raw, e.opts.listSeparator)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", binding.Name, err)
+		}
+
+		convert.Set(values, binding.leaf.Names(), value)
+	}
+
+	return values, nil
+}
+
+func (o options) name(leaf schema.Leaf) string {
+	name := schema.Options(leaf.Field()).GetEnv()
+	if name != "" {
+		return name
+	}
+
+	parts := leaf.Names()
+	if o.prefix != "" {
+		parts = append([]string{o.prefix}, parts...)
+	}
+
+	return strings.ToUpper(strings.Join(parts, o.separator))
+}
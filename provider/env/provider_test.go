@@ -0,0 +1,139 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gosynergy/protoconf"
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+)
+
+func lookupEnv(envs map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := envs[name]
+
+		return value, ok
+	}
+}
+
+func TestEnv_Read(t *testing.T) {
+	t.Parallel()
+
+	provider := Provider(
+		(&v1.ConfigWithDefaults{}).ProtoReflect().Descriptor(),
+		WithPrefix("APP"),
+		WithLookupEnv(lookupEnv(map[string]string{
+			"APP_SERVER_ADDR":      ":80",
+			"APP_SERVER_TIMEOUT":   "150ms",
+			"APP_SERVER_MAX_CONNS": "10",
+			"APP_SERVER_HOSTS":     "a, b",
+			"APP_LOG_LEVEL":        "LEVEL_DEBUG",
+			"APP_LOG_JSON":         "false",
+			"APP_RATIO":            "0.25",
+			"SERVER_ADDR":          ":8080",
+		})),
+	)
+
+	values, err := provider.Read()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"server": map[string]interface{}{
+			"addr":      ":80",
+			"timeout":   "0.150s",
+			"max_conns": uint64(10),
+			"hosts":     []interface{}{"a", "b"},
+		},
+		"log": map[string]interface{}{
+			"level": "LEVEL_DEBUG",
+			"json":  false,
+		},
+		"ratio": 0.25,
+	}, values)
+}
+
+func TestEnv_ReadCollections(t *testing.T) {
+	t.Parallel()
+
+	provider := Provider(
+		(&v1.ConfigWithMerge{}).ProtoReflect().Descriptor(),
+		WithSeparator("__"),
+		WithListSeparator(";"),
+		WithLookupEnv(lookupEnv(map[string]string{
+			"LISTENERS": `[{"name": "http", "addr": ":80"}]`,
+			"TAGS":      "a;b",
+			"LABELS":    "team=core;tier=1",
+		})),
+	)
+
+	values, err := provider.Read()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"listeners": []interface{}{
+			map[string]interface{}{"name": "http", "addr": ":80"},
+		},
+		"tags":   []interface{}{"a", "b"},
+		"labels": map[string]interface{}{"team": "core", "tier": "1"},
+	}, values)
+}
+
+func TestEnv_ReadInvalidValue(t *testing.T) {
+	t.Parallel()
+
+	provider := Provider(
+		(&v1.ConfigWithDefaults{}).ProtoReflect().Descriptor(),
+		WithLookupEnv(lookupEnv(map[string]string{
+			"SERVER_MAX_CONNS": "many",
+		})),
+	)
+
+	_, err := provider.Read()
+	require.ErrorContains(t, err, "SERVER_MAX_CONNS")
+}
+
+func TestEnv_Bindings(t *testing.T) {
+	t.Parallel()
+
+	provider := Provider((&v1.Config{}).ProtoReflect().Descriptor(), WithPrefix("app"))
+
+	names := make(map[string]string)
+	for _, binding := range provider.Bindings() {
+		names[binding.Path] = binding.Name
+	}
+
+	assert.Equal(t, "APP_SERVER_HTTP_ADDR", names["server.http.addr"])
+	assert.Equal(t, "APP_DATA_REDIS_READ_TIMEOUT", names["data.redis.read_timeout"])
+	assert.Equal(t, "DATABASE_URL", names["data.database.source"])
+}
+
+func TestEnv_Layer(t *testing.T) {
+	t.Parallel()
+
+	loader, err := protoconf.New(
+		protoconf.WithLayer(file.Provider("../../conf/config.yaml"), yaml.Parser()),
+		protoconf.WithProvider(Provider(
+			(&v1.Config{}).ProtoReflect().Descriptor(),
+			WithPrefix("APP"),
+			WithLookupEnv(lookupEnv(map[string]string{
+				"APP_SERVER_HTTP_ADDR":        "0.0.0.0:80",
+				"APP_DATA_REDIS_READ_TIMEOUT": "1m",
+				"DATABASE_URL":                "postgres://localhost/test",
+			})),
+		)),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.Config
+	require.NoError(t, loader.Scan(&cfg))
+
+	assert.Equal(t, "0.0.0.0:80", cfg.GetServer().GetHttp().GetAddr())
+	assert.Equal(t, "0.0.0.0:9000", cfg.GetServer().GetGrpc().GetAddr())
+	assert.Equal(t, int64(60), cfg.GetData().GetRedis().GetReadTimeout().GetSeconds())
+	assert.Equal(t, "postgres://localhost/test", cfg.GetData().GetDatabase().GetSource())
+}
@@ -0,0 +1,32 @@
+// Package file provides a file provider exposing the file path.
+package file
+
+import (
+	"github.com/knadh/koanf/providers/file"
+)
+
+// File is a koanf file provider exposing the file path, so the loader
+// can explain the origin of the values.
+type File struct {
+	*file.File
+
+	path string
+}
+
+// Provider creates a new File provider.
+func Provider(path string) *File {
+	return &File{
+		File: file.Provider(path),
+		path: path,
+	}
+}
+
+// Path returns the path of the file.
+func (f *File) Path() string {
+	return f.path
+}
+
+// Name returns the provider name.
+func (f *File) Name() string {
+	return "file"
+}
@@ -0,0 +1,28 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gosynergy/protoconf"
+)
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	var provider interface {
+		protoconf.Provider
+		protoconf.FileProvider
+		protoconf.Named
+		protoconf.Watcher
+	} = Provider("../../conf/config.yaml")
+
+	assert.Equal(t, "../../conf/config.yaml", provider.Path())
+	assert.Equal(t, "file", provider.Name())
+
+	data, err := provider.ReadBytes()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "server:")
+}
@@ -0,0 +1,151 @@
+package protoconf
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/gosynergy/protoconf/internal/schema"
+)
+
+// Redacted is the mask of the sensitive string and bytes values.
+const Redacted = "[REDACTED]"
+
+// Redact returns a copy of the message with the values of the fields marked
+// with the `(protoconf.field).sensitive` option masked, so the configuration
+// can be logged. String and bytes values are replaced with Redacted, other
+// values are cleared. The sensitive fields are masked in the nested
+// messages, the lists and maps of messages and the Any messages. The Any
+// messages of an unknown type are cleared, as they cannot be inspected.
+func Redact(message proto.Message) proto.Message {
+	redacted := proto.Clone(message)
+	redact(redacted.ProtoReflect())
+
+	return redacted
+}
+
+// MarshalRedacted returns the JSON of the redacted message, see Redact.
+func MarshalRedacted(message proto.Message) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(Redact(message))
+	if err != nil {
+		return nil, fmt.Errorf("protojson marshal: %w", err)
+	}
+
+	return data, nil
+}
+
+func redact(m protoreflect.Message) {
+	if m.Descriptor().FullName() == "google.protobuf.Any" {
+		redactAny(m)
+
+		return
+	}
+
+	m.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if schema.Options(fd).GetSensitive() {
+			mask(m, fd, value)
+
+			return true
+		}
+
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				value.Map().Range(func(_ protoreflect.MapKey, elem protoreflect.Value) bool {
+					redact(elem.Message())
+
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				list := value.List()
+				for i := 0; i < list.Len(); i++ {
+					redact(list.Get(i).Message())
+				}
+			}
+		case fd.Message() != nil:
+			redact(value.Message())
+		}
+
+		return true
+	})
+}
+
+// mask masks the value of the sensitive field.
+func mask(m protoreflect.Message, fd protoreflect.FieldDescriptor, value protoreflect.Value) {
+	masked, ok := maskedValue(fd.Kind())
+	if fd.IsMap() {
+		masked, ok = maskedValue(fd.MapValue().Kind())
+	}
+
+	if !ok {
+		m.Clear(fd)
+
+		return
+	}
+
+	switch {
+	case fd.IsMap():
+		entries := value.Map()
+		entries.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+			entries.Set(key, masked)
+
+			return true
+		})
+	case fd.IsList():
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			list.Set(i, masked)
+		}
+	default:
+		m.Set(fd, masked)
+	}
+}
+
+func maskedValue(kind protoreflect.Kind) (protoreflect.Value, bool) {
+	switch kind {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(Redacted), true
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(Redacted)), true
+	default:
+		return protoreflect.Value{}, false
+	}
+}
+
+// redactAny redacts the message packed in the Any. The value is cleared if
+// the message type is unknown or the value cannot be unmarshalled.
+func redactAny(m protoreflect.Message) {
+	fields := m.Descriptor().Fields()
+	typeURL := fields.ByName("type_url")
+	value := fields.ByName("value")
+
+	if !m.Has(value) {
+		return
+	}
+
+	packed, err := anypb.UnmarshalNew(&anypb.Any{
+		TypeUrl: m.Get(typeURL).String(),
+		Value:   m.Get(value).Bytes(),
+	}, proto.UnmarshalOptions{})
+	if err != nil {
+		m.Clear(value)
+
+		return
+	}
+
+	redact(packed.ProtoReflect())
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(packed)
+	if err != nil {
+		m.Clear(value)
+
+		return
+	}
+
+	m.Set(value, protoreflect.ValueOfBytes(data))
+}
@@ -0,0 +1,90 @@
+package protoconf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+)
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	extension, err := anypb.New(&v1.ConfigWithSensitive_Credentials{User: "ext", Password: "secret"})
+	require.NoError(t, err)
+
+	cfg := &v1.ConfigWithSensitive{
+		Users: []*v1.ConfigWithSensitive_Credentials{
+			{User: "alice", Password: "secret"},
+			{User: "bob"},
+		},
+		Backends: map[string]*v1.ConfigWithSensitive_Credentials{
+			"db": {User: "root", Password: "secret"},
+		},
+		Extension:  extension,
+		Tokens:     []string{"a", "b"},
+		Keys:       map[string]string{"signing": "secret"},
+		PrivateKey: []byte("secret"),
+		Pin:        1234,
+		Admin:      &v1.ConfigWithSensitive_Credentials{User: "admin", Password: "secret"},
+	}
+	original := proto.Clone(cfg)
+
+	redacted, ok := Redact(cfg).(*v1.ConfigWithSensitive)
+	require.True(t, ok)
+
+	redactedExtension, err := anypb.New(&v1.ConfigWithSensitive_Credentials{User: "ext", Password: Redacted})
+	require.NoError(t, err)
+
+	expected := &v1.ConfigWithSensitive{
+		Users: []*v1.ConfigWithSensitive_Credentials{
+			{User: "alice", Password: Redacted},
+			{User: "bob"},
+		},
+		Backends: map[string]*v1.ConfigWithSensitive_Credentials{
+			"db": {User: "root", Password: Redacted},
+		},
+		Extension:  redactedExtension,
+		Tokens:     []string{Redacted, Redacted},
+		Keys:       map[string]string{"signing": Redacted},
+		PrivateKey: []byte(Redacted),
+	}
+	assert.True(t, proto.Equal(expected, redacted), "got %v", redacted)
+	assert.True(t, proto.Equal(original, cfg), "the message is modified")
+}
+
+func TestRedact_UnknownAny(t *testing.T) {
+	t.Parallel()
+
+	cfg := &v1.ConfigWithSensitive{
+		Extension: &anypb.Any{TypeUrl: "type.googleapis.com/unknown.Message", Value: []byte("secret")},
+	}
+
+	redacted, ok := Redact(cfg).(*v1.ConfigWithSensitive)
+	require.True(t, ok)
+
+	assert.Equal(t, "type.googleapis.com/unknown.Message", redacted.GetExtension().GetTypeUrl())
+	assert.Empty(t, redacted.GetExtension().GetValue())
+}
+
+func TestMarshalRedacted(t *testing.T) {
+	t.Parallel()
+
+	cfg := &v1.Config{
+		Data: &v1.Config_Data{
+			Database: &v1.Config_Data_Database{
+				Driver: "mysql",
+				Source: "root:root@tcp(127.0.0.1:3306)/test",
+			},
+		},
+	}
+
+	data, err := MarshalRedacted(cfg)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"data": {"database": {"driver": "mysql", "source": "[REDACTED]"}}}`, string(data))
+}
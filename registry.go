@@ -0,0 +1,151 @@
+package protoconf
+
+import (
+	"bytes"
+	"mime"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/knadh/koanf/parsers/json"
+	"github.com/knadh/koanf/parsers/toml"
+	"github.com/knadh/koanf/parsers/yaml"
+)
+
+// The canonical extensions of the formats detected by Registry.Sniff.
+const (
+	ExtJSON      = ".json"
+	ExtYAML      = ".yaml"
+	ExtTOML      = ".toml"
+	ExtProtoText = ".txtpb"
+	ExtProto     = ".pb"
+)
+
+// Registry selects the parser of a configuration by its file extension, its
+// content type or its content.
+type Registry struct {
+	extensions   map[string]Parser
+	contentTypes map[string]Parser
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		extensions:   make(map[string]Parser),
+		contentTypes: make(map[string]Parser),
+	}
+}
+
+// DefaultRegistry creates a Registry of the koanf JSON, YAML and TOML
+// parsers. The parsers of the protobuf text and binary formats depend on
+// the configuration message, see the protobuf parser package.
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+
+	r.Register(json.Parser(), ExtJSON, "application/json")
+	r.Register(yaml.Parser(), ExtYAML, ".yml", "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml")
+	r.Register(toml.Parser(), ExtTOML, "application/toml")
+
+	return r
+}
+
+// Register registers the parser for the file extensions, e.g. `.yaml`, and
+// the content types, e.g. `application/yaml`. The keys starting with a dot
+// are extensions, the others are content types.
+func (r *Registry) Register(p Parser, keys ...string) {
+	for _, key := range keys {
+		if strings.HasPrefix(key, ".") {
+			r.extensions[strings.ToLower(key)] = p
+		} else {
+			r.contentTypes[strings.ToLower(key)] = p
+		}
+	}
+}
+
+// ByExtension returns the parser of the file extension of the path.
+func (r *Registry) ByExtension(path string) (Parser, bool) {
+	ext := filepath.Ext(path)
+	if ext == "" {
+		return nil, false
+	}
+
+	p, ok := r.extensions[strings.ToLower(ext)]
+
+	return p, ok
+}
+
+// ByContentType returns the parser of the content type, e.g.
+// `application/json; charset=utf-8`.
+func (r *Registry) ByContentType(contentType string) (Parser, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+
+	p, ok := r.contentTypes[mediaType]
+
+	return p, ok
+}
+
+// Sniff returns the parser of the format detected from the content, see
+// Detect. The parser is the one registered for the canonical extension of
+// the format, e.g. `.toml`.
+func (r *Registry) Sniff(data []byte) (Parser, bool) {
+	p, ok := r.extensions[Detect(data)]
+
+	return p, ok
+}
+
+var (
+	tomlTable      = regexp.MustCompile(`^\[\[?[\w.\-" ]+\]\]?\s*(#.*)?$`)
+	tomlKeyValue   = regexp.MustCompile(`^[\w.\-"]+\s*=`)
+	protoTextField = regexp.MustCompile(`^(\w+|\[[\w./]+\])\s*\{`)
+)
+
+// Detect returns the canonical extension of the format of the content:
+// ExtProto for binary data, ExtTOML, ExtJSON or ExtProtoText if the first
+// significant line looks like a table or a key/value pair, a JSON object or
+// a protobuf text message field, and ExtYAML otherwise.
+func Detect(data []byte) string {
+	if isBinary(data) {
+		return ExtProto
+	}
+
+	line := firstLine(data)
+
+	switch {
+	case tomlTable.MatchString(line), tomlKeyValue.MatchString(line):
+		return ExtTOML
+	case strings.HasPrefix(line, "{"):
+		return ExtJSON
+	case protoTextField.MatchString(line):
+		return ExtProtoText
+	default:
+		return ExtYAML
+	}
+}
+
+// isBinary reports whether the data is not text: invalid UTF-8 or control
+// characters other than whitespace.
+func isBinary(data []byte) bool {
+	if !utf8.Valid(data) {
+		return true
+	}
+
+	return bytes.ContainsFunc(data, func(r rune) bool {
+		return r < 0x20 && r != '\t' && r != '\n' && r != '\r'
+	})
+}
+
+// firstLine returns the first line which is neither blank nor a comment.
+func firstLine(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "---") {
+			return line
+		}
+	}
+
+	return ""
+}
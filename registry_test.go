@@ -0,0 +1,154 @@
+package protoconf
+
+import (
+	"testing"
+	"time"
+
+	koanffile "github.com/knadh/koanf/providers/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+	"github.com/gosynergy/protoconf/provider/file"
+)
+
+// contentTypeProvider is a provider of data with a content type.
+type contentTypeProvider struct {
+	memoryProvider
+
+	contentType string
+}
+
+func (p *contentTypeProvider) ContentType() string {
+	return p.contentType
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		ext  string
+	}{
+		{name: "json", data: ` {"server": {}}`, ext: ExtJSON},
+		{name: "yaml", data: "# config\nserver:\n  addr: :80\n", ext: ExtYAML},
+		{name: "yaml document", data: "---\nserver: {}\n", ext: ExtYAML},
+		{name: "yaml flow", data: "server: {http: {addr: 127.0.0.1:8080}}", ext: ExtYAML},
+		{name: "toml table", data: "# config\n\n[server.http]\naddr = \":80\"\n", ext: ExtTOML},
+		{name: "toml array table", data: "[[listeners]]\nname = \"http\"\n", ext: ExtTOML},
+		{name: "toml key", data: "name = \"app\"\n", ext: ExtTOML},
+		{name: "prototext", data: "# proto-message: conf.v1.Config\nserver {\n}\n", ext: ExtProtoText},
+		{name: "prototext extension", data: "[ext.field] {\n}\n", ext: ExtProtoText},
+		{name: "binary", data: "\x0a\x06\x0a\x04\x0a\x02:8", ext: ExtProto},
+		{name: "invalid utf8", data: "\xff\xfe", ext: ExtProto},
+		{name: "empty", data: "", ext: ExtYAML},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.ext, Detect([]byte(test.data)))
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	r := DefaultRegistry()
+
+	_, ok := r.ByExtension("conf/config.YML")
+	assert.True(t, ok)
+
+	_, ok = r.ByExtension("conf/config")
+	assert.False(t, ok)
+
+	_, ok = r.ByExtension("conf/config.ini")
+	assert.False(t, ok)
+
+	_, ok = r.ByContentType("application/json; charset=utf-8")
+	assert.True(t, ok)
+
+	_, ok = r.ByContentType("text/html")
+	assert.False(t, ok)
+
+	_, ok = r.Sniff([]byte("\x0a\x00"))
+	assert.False(t, ok)
+}
+
+func TestConfigLoader_DetectParser(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		provider Provider
+	}{
+		{
+			name:     "file extension",
+			provider: file.Provider("conf/config.toml"),
+		},
+		{
+			name:     "sniffed file",
+			provider: koanffile.Provider("conf/config.toml"),
+		},
+		{
+			name: "content type",
+			provider: &contentTypeProvider{
+				memoryProvider: memoryProvider{data: []byte(`server: {http: {addr: "127.0.0.1:8080", timeout: 2s}}
+data: {database: {driver: mysql}}`)},
+				contentType: "application/yaml",
+			},
+		},
+		{
+			name: "unknown content type",
+			provider: &contentTypeProvider{
+				memoryProvider: memoryProvider{data: []byte(`{
+					"server": {"http": {"addr": "127.0.0.1:8080", "timeout": "2s"}},
+					"data": {"database": {"driver": "mysql"}}
+				}`)},
+				contentType: "text/plain",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			loader, err := New(WithProvider(test.provider))
+			require.NoError(t, err)
+			require.NoError(t, loader.Load())
+
+			var cfg v1.Config
+			require.NoError(t, loader.Scan(&cfg))
+
+			assert.Equal(t, "127.0.0.1:8080", cfg.GetServer().GetHttp().GetAddr())
+			assert.Equal(t, 2*time.Second, cfg.GetServer().GetHttp().GetTimeout().AsDuration())
+			assert.Equal(t, "mysql", cfg.GetData().GetDatabase().GetDriver())
+		})
+	}
+}
+
+func TestConfigLoader_DetectParser_Errors(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(WithProvider(&contentTypeProvider{
+		memoryProvider: memoryProvider{data: []byte("\x0a\x06\x0a\x04\x0a\x02:8")},
+		contentType:    "application/octet-stream",
+	}))
+	require.NoError(t, err)
+	require.ErrorIs(t, loader.Load(), ErrUnknownFormat)
+
+	loader, err = New(
+		WithProvider(koanffile.Provider("conf/config.toml")),
+		WithParserRegistry(nil),
+	)
+	require.NoError(t, err)
+	require.Error(t, loader.Load())
+}
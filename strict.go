@@ -0,0 +1,201 @@
+package protoconf
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/gosynergy/protoconf/internal/schema"
+)
+
+var ErrUnknownField = errors.New("unknown field")
+
+// UnknownFieldError reports a configuration key which is not a field of
+// the message, see WithStrict.
+type UnknownFieldError struct {
+	// Path is the dotted path of the key, e.g. `data.redis.read_timout`.
+	Path string
+	// Suggestion is the name of the most similar field, if any.
+	Suggestion string
+	// Origin is the origin of the key, if known.
+	Origin *Origin
+}
+
+func (e *UnknownFieldError) Error() string {
+	msg := fmt.Sprintf("unknown field %q", e.Path)
+
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %q?", e.Suggestion)
+	}
+
+	if e.Origin != nil {
+		msg += " from " + e.Origin.String()
+	}
+
+	return msg
+}
+
+func (e *UnknownFieldError) Unwrap() error {
+	return ErrUnknownField
+}
+
+// unknownFields returns an UnknownFieldError for every key of values which
+// is not a field of the message.
+func unknownFields(
+	desc protoreflect.MessageDescriptor,
+	values map[string]interface{},
+	path string,
+	origins map[string]Origin,
+) []error {
+	var errs []error
+
+	for _, key := range sortedKeys(values) {
+		keyPath := joinPath(path, key)
+
+		fd := schema.FieldByKey(desc, key)
+		if fd == nil {
+			unknownErr := &UnknownFieldError{
+				Path:       keyPath,
+				Suggestion: suggestField(desc, key),
+			}
+
+			origin, ok := explainKey(origins, keyPath)
+			if ok {
+				unknownErr.Origin = &origin
+			}
+
+			errs = append(errs, unknownErr)
+
+			continue
+		}
+
+		errs = append(errs, unknownFieldsOfValue(fd, values[key], keyPath, origins)...)
+	}
+
+	return errs
+}
+
+func unknownFieldsOfValue(
+	fd protoreflect.FieldDescriptor,
+	value interface{},
+	path string,
+	origins map[string]Origin,
+) []error {
+	var errs []error
+
+	switch {
+	case fd.IsMap():
+		entries, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		for _, key := range sortedKeys(entries) {
+			errs = append(errs, unknownFieldsOfMessage(fd.MapValue(), entries[key], joinPath(path, key), origins)...)
+		}
+	case fd.IsList():
+		elems, ok := value.([]interface{})
+		if !ok {
+			return nil
+		}
+
+		for i, elem := range elems {
+			errs = append(errs, unknownFieldsOfMessage(fd, elem, path+"["+strconv.Itoa(i)+"]", origins)...)
+		}
+	default:
+		errs = unknownFieldsOfMessage(fd, value, path, origins)
+	}
+
+	return errs
+}
+
+func unknownFieldsOfMessage(
+	fd protoreflect.FieldDescriptor,
+	value interface{},
+	path string,
+	origins map[string]Origin,
+) []error {
+	msgDesc := fd.Message()
+	if msgDesc == nil || schema.IsWellKnownType(msgDesc) {
+		return nil
+	}
+
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	return unknownFields(msgDesc, values, path, origins)
+}
+
+// explainKey returns the origin of the key, which is the origin of any
+// of its leaves if the key holds a map.
+func explainKey(origins map[string]Origin, path string) (Origin, bool) {
+	origin, ok := explain(origins, path)
+	if ok {
+		return origin, true
+	}
+
+	for _, leafPath := range sortedOriginPaths(origins) {
+		if strings.HasPrefix(leafPath, path+".") {
+			return origins[leafPath], true
+		}
+	}
+
+	return Origin{}, false
+}
+
+// suggestField returns the name of the field most similar to the key or
+// an empty string if no field is similar enough.
+func suggestField(desc protoreflect.MessageDescriptor, key string) string {
+	var (
+		suggestion string
+		best       = len(key)/2 + 1
+	)
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		for _, name := range []string{string(fd.Name()), fd.JSONName()} {
+			distance := levenshtein(strings.ToLower(key), strings.ToLower(name))
+			if distance < best {
+				best = distance
+				suggestion = string(fd.Name())
+			}
+		}
+	}
+
+	return suggestion
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,98 @@
+package protoconf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+)
+
+func TestConfigLoader_ScanStrict(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/unknown-key-config.yaml")),
+		WithParser(yaml.Parser()),
+		WithStrict(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.Config
+	err = loader.Scan(&cfg)
+	require.ErrorIs(t, err, ErrUnknownField)
+
+	var joined interface{ Unwrap() []error }
+
+	require.True(t, errors.As(err, &joined))
+
+	var unknown []UnknownFieldError
+
+	for _, e := range joined.Unwrap() {
+		var unknownErr *UnknownFieldError
+
+		require.True(t, errors.As(e, &unknownErr))
+
+		unknown = append(unknown, UnknownFieldError{
+			Path:       unknownErr.Path,
+			Suggestion: unknownErr.Suggestion,
+		})
+	}
+
+	assert.Equal(t, []UnknownFieldError{
+		{Path: "data.redis.read_timout", Suggestion: "read_timeout"},
+		{Path: "server.debug"},
+	}, unknown)
+	assert.Contains(t, err.Error(), `unknown field "data.redis.read_timout", did you mean "read_timeout"?`)
+}
+
+func TestConfigLoader_ScanStrictValid(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/config.yaml")),
+		WithParser(yaml.Parser()),
+		WithStrict(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.Config
+	require.NoError(t, loader.Scan(&cfg))
+}
+
+func TestConfigLoader_ScanNonStrictIgnoresUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithProvider(file.Provider("conf/unknown-key-config.yaml")),
+		WithParser(yaml.Parser()),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load())
+
+	var cfg v1.Config
+	require.NoError(t, loader.Scan(&cfg))
+	assert.Nil(t, cfg.GetData().GetRedis().GetReadTimeout())
+}
+
+func TestUnknownFields_NestedCollections(t *testing.T) {
+	t.Parallel()
+
+	desc := (&v1.ConfigWithMerge{}).ProtoReflect().Descriptor()
+	errs := unknownFields(desc, map[string]interface{}{
+		"listeners": []interface{}{
+			map[string]interface{}{"name": "http"},
+			map[string]interface{}{"nmae": "grpc"},
+		},
+		"labels": map[string]interface{}{"any": "value"},
+	}, "", nil)
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, &UnknownFieldError{Path: "listeners[1].nmae", Suggestion: "name"}, errs[0])
+}
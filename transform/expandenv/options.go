@@ -1,14 +1,124 @@
 package expandenv
 
+import "strings"
+
 // Option is config option.
 type Option func(*options)
 
 type options struct {
-	getenv func(string) string
+	lookupEnv  func(string) (string, bool)
+	coerce     bool
+	expandKeys bool
+	strict     bool
+	noArith    bool
+	allowlist  map[string]bool
+	prefixes   []string
+	report     func(Report)
 }
 
+// WithGetenv sets the function used to look up the variables. The empty
+// variables are treated as unset.
 func WithGetenv(getenv func(string) string) Option {
 	return func(opts *options) {
-		opts.getenv = getenv
+		opts.lookupEnv = func(name string) (string, bool) {
+			value := getenv(name)
+
+			return value, value != ""
+		}
+	}
+}
+
+// WithLookupEnv sets the function used to look up the variables.
+// The default is os.LookupEnv.
+func WithLookupEnv(lookupEnv func(string) (string, bool)) Option {
+	return func(opts *options) {
+		opts.lookupEnv = lookupEnv
+	}
+}
+
+// WithCoerce converts the strings which are a single expansion, e.g.
+// `${PORT}`, to a number or a boolean if the expanded value is one, e.g.
+// `8080` or `true`. The other strings stay strings.
+func WithCoerce() Option {
+	return func(opts *options) {
+		opts.coerce = true
+	}
+}
+
+// WithExpandKeys also expands the variables in the map keys. Two keys
+// expanded to the same key are an error.
+func WithExpandKeys() Option {
+	return func(opts *options) {
+		opts.expandKeys = true
+	}
+}
+
+// WithStrict makes the expansion of an unset variable without a default,
+// e.g. `${PORT}` but not `${PORT:-8080}`, fail with ErrUnset instead of
+// expanding to an empty string.
+func WithStrict() Option {
+	return func(opts *options) {
+		opts.strict = true
+	}
+}
+
+// WithoutArithmetic makes the arithmetic expansions, e.g. `$((PORT + 1))`,
+// fail with ErrForbidden. The command substitutions are never run.
+func WithoutArithmetic() Option {
+	return func(opts *options) {
+		opts.noArith = true
 	}
 }
+
+// WithAllowlist restricts the expansion to the variables. The expansion of
+// other variables fails with ErrNotAllowed. It can be combined with
+// WithAllowedPrefix.
+func WithAllowlist(names ...string) Option {
+	return func(opts *options) {
+		if opts.allowlist == nil {
+			opts.allowlist = make(map[string]bool)
+		}
+
+		for _, name := range names {
+			opts.allowlist[name] = true
+		}
+	}
+}
+
+// WithAllowedPrefix restricts the expansion to the variables with
+// the prefix, e.g. `APP_`. The expansion of other variables fails with
+// ErrNotAllowed. It can be combined with WithAllowlist.
+func WithAllowedPrefix(prefix string) Option {
+	return func(opts *options) {
+		opts.prefixes = append(opts.prefixes, prefix)
+	}
+}
+
+// WithReport sets the function called with the report of the variables
+// consulted by every transformation, failed or not, e.g. to audit what
+// the configuration depends on. The variables which are not allowed are
+// reported as denied.
+func WithReport(fn func(Report)) Option {
+	return func(opts *options) {
+		opts.report = fn
+	}
+}
+
+// allowed reports whether the variable can be expanded.
+func (o options) allowed(name string) bool {
+	if o.allowlist == nil && o.prefixes == nil {
+		return true
+	}
+
+	if o.allowlist[name] {
+		return true
+	}
+
+	for _, prefix := range o.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
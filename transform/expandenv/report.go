@@ -0,0 +1,116 @@
+package expandenv
+
+import (
+	"sort"
+
+	"mvdan.cc/sh/v3/expand"
+)
+
+// Report lists the variables consulted by a transformation.
+type Report struct {
+	// Variables are the consulted variables sorted by name.
+	Variables []Variable
+}
+
+// Variable is a variable consulted by a transformation.
+type Variable struct {
+	// Name is the variable name.
+	Name string
+	// Set reports whether the variable was set.
+	Set bool
+	// Denied reports whether the variable is not allowed, see WithAllowlist
+	// and WithAllowedPrefix. A denied variable is not looked up.
+	Denied bool
+	// Paths are the dotted paths of the values referencing the variable,
+	// e.g. `data.database.source`.
+	Paths []string
+}
+
+// ignoredVariables are looked up by the shell expansion itself.
+var ignoredVariables = map[string]bool{
+	"IFS": true,
+}
+
+// environ is the expand.Environ of a transformation recording
+// the consulted variables.
+type environ struct {
+	opts options
+
+	// path is the path of the value being expanded.
+	path      string
+	variables map[string]*Variable
+	denied    []string
+}
+
+var _ expand.Environ = (*environ)(nil)
+
+func newEnviron(opts options) *environ {
+	return &environ{
+		opts:      opts,
+		variables: make(map[string]*Variable),
+	}
+}
+
+func (e *environ) Get(name string) expand.Variable {
+	if ignoredVariables[name] {
+		return expand.Variable{}
+	}
+
+	if !e.opts.allowed(name) {
+		e.denied = append(e.denied, name)
+		e.record(name, false).Denied = true
+
+		return expand.Variable{}
+	}
+
+	value, ok := e.opts.lookupEnv(name)
+	e.record(name, ok)
+
+	if !ok {
+		return expand.Variable{}
+	}
+
+	return expand.Variable{Exported: true, Kind: expand.String, Str: value}
+}
+
+// Each does nothing, the variables cannot be listed.
+func (e *environ) Each(func(name string, vr expand.Variable) bool) {}
+
+// record records the variable at the current path and returns it.
+func (e *environ) record(name string, set bool) *Variable {
+	variable, ok := e.variables[name]
+	if !ok {
+		variable = &Variable{Name: name, Set: set}
+		e.variables[name] = variable
+	}
+
+	if len(variable.Paths) == 0 || variable.Paths[len(variable.Paths)-1] != e.path {
+		variable.Paths = append(variable.Paths, e.path)
+	}
+
+	return variable
+}
+
+func (e *environ) report() Report {
+	report := Report{
+		Variables: make([]Variable, 0, len(e.variables)),
+	}
+
+	for _, variable := range e.variables {
+		paths := append([]string(nil), variable.Paths...)
+		sort.Strings(paths)
+
+		report.Variables = append(report.Variables, Variable{
+			Name:   variable.Name,
+			Set:    variable.Set,
+			Denied: variable.Denied,
+			Paths:  paths,
+		})
+	}
+
+	sort.Slice(report.Variables, func(i, j int) bool {
+		return report.Variables[i].Name < report.Variables[j].Name
+	})
+
+	return report
+}
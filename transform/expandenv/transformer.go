@@ -1,38 +1,68 @@
+// Package expandenv expands the environment variables in the configuration
+// values, e.g. `${DB_HOST}` or `${PORT:-8080}`.
 package expandenv
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
-	"mvdan.cc/sh/v3/shell"
+	"mvdan.cc/sh/v3/expand"
+	"mvdan.cc/sh/v3/syntax"
 )
 
+var (
+	ErrDuplicateKey = errors.New("duplicate key")
+	ErrUnset        = errors.New("variable is not set")
+	ErrNotAllowed   = errors.New("variable is not allowed")
+	ErrForbidden    = errors.New("expansion is forbidden")
+)
+
+// Transformer expands the environment variables in the configuration values
+// with the shell syntax. Every string is expanded on its own, so a variable
+// value cannot change the structure of the configuration.
+//
+// By default an unset variable expands to an empty string and any variable
+// can be expanded, see WithStrict, WithAllowlist and WithAllowedPrefix.
 type Transformer struct {
 	opts options
 }
 
+// singleExpansion matches a string which is a single parameter expansion,
+// e.g. `${PORT}` or `${PORT:-8080}`.
+var singleExpansion = regexp.MustCompile(`^\$\{[^{}]+\}$`)
+
+// Transform expands every value. The failed values are kept as they are,
+// the expanded values are returned with the errors of the failed ones
+// joined with errors.Join.
 func (t *Transformer) Transform(values map[string]interface{}) (map[string]interface{}, error) {
-	data, err := json.Marshal(values)
-	if err != nil {
-		return nil, fmt.Errorf("json marshal: %w", err)
+	e := &expansion{
+		opts: t.opts,
+		env:  newEnviron(t.opts),
 	}
 
-	content, err := shell.Expand(string(data), t.opts.getenv)
-	if err != nil {
-		return nil, fmt.Errorf("expand: %w", err)
-	}
+	expanded := e.expandMap(values, "")
 
-	var expanded map[string]interface{}
+	if t.opts.report != nil {
+		t.opts.report(e.env.report())
+	}
 
-	err = json.Unmarshal([]byte(content), &expanded)
-	if err != nil {
-		return nil, fmt.Errorf("json unmarshal: %w", err)
+	if len(e.errs) > 0 {
+		return expanded, errors.Join(e.errs...)
 	}
 
 	return expanded, nil
 }
 
+func (t *Transformer) Name() string {
+	return "expandenv"
+}
+
 func NewTransformer(opts ...Option) *Transformer {
 	confOpts := options{}
 
@@ -40,11 +70,186 @@ func NewTransformer(opts ...Option) *Transformer {
 		opt(&confOpts)
 	}
 
-	if confOpts.getenv == nil {
-		confOpts.getenv = os.Getenv
+	if confOpts.lookupEnv == nil {
+		confOpts.lookupEnv = os.LookupEnv
 	}
 
 	return &Transformer{
 		opts: confOpts,
 	}
 }
+
+// expansion is a single transformation with its errors.
+type expansion struct {
+	opts options
+	env  *environ
+	errs []error
+}
+
+func (t *expansion) expandMap(values map[string]interface{}, path string) map[string]interface{} {
+	// The keys are sorted to report the errors in a stable order.
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	expanded := make(map[string]interface{}, len(values))
+
+	for _, key := range keys {
+		expandedKey := key
+
+		if t.opts.expandKeys {
+			var err error
+
+			expandedKey, err = t.expandString(key, joinPath(path, key))
+			if err != nil {
+				t.errs = append(t.errs, err)
+				expandedKey = key
+			}
+
+			_, ok := expanded[expandedKey]
+			if ok {
+				t.errs = append(t.errs, fmt.Errorf("%s: %w", joinPath(path, expandedKey), ErrDuplicateKey))
+
+				continue
+			}
+		}
+
+		expanded[expandedKey] = t.expand(values[key], joinPath(path, key))
+	}
+
+	return expanded
+}
+
+func (t *expansion) expand(value interface{}, path string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return t.expandMap(v, path)
+	case []interface{}:
+		expanded := make([]interface{}, len(v))
+		for i, elem := range v {
+			expanded[i] = t.expand(elem, path+"["+strconv.Itoa(i)+"]")
+		}
+
+		return expanded
+	case string:
+		expanded, err := t.expandString(v, path)
+		if err != nil {
+			t.errs = append(t.errs, err)
+
+			return v
+		}
+
+		if t.opts.coerce && singleExpansion.MatchString(v) {
+			return coerce(expanded)
+		}
+
+		return expanded
+	default:
+		return v
+	}
+}
+
+func (t *expansion) expandString(s, path string) (string, error) {
+	word, err := syntax.NewParser().Document(strings.NewReader(s))
+	if err != nil {
+		return "", &valueError{path: path, err: err}
+	}
+
+	err = t.check(word)
+	if err != nil {
+		return "", &valueError{path: path, err: err}
+	}
+
+	t.env.path = path
+	t.env.denied = nil
+
+	expanded, err := expand.Document(&expand.Config{Env: t.env, NoUnset: t.opts.strict}, word)
+	if len(t.env.denied) > 0 {
+		return "", &valueError{path: path, err: fmt.Errorf("%w: %s", ErrNotAllowed, strings.Join(t.env.denied, ", "))}
+	}
+
+	var unsetErr expand.UnsetParameterError
+	if errors.As(err, &unsetErr) {
+		return "", &valueError{path: path, err: fmt.Errorf("%w: %s", ErrUnset, unsetErr.Node.Param.Value)}
+	}
+
+	if err != nil {
+		return "", &valueError{path: path, err: err}
+	}
+
+	return expanded, nil
+}
+
+// valueError is the error of the value at a path.
+type valueError struct {
+	path string
+	err  error
+}
+
+func (e *valueError) Error() string {
+	return "expand " + e.path + ": " + e.err.Error()
+}
+
+// ValuePath returns the dotted path of the value.
+func (e *valueError) ValuePath() string {
+	return e.path
+}
+
+func (e *valueError) Unwrap() error {
+	return e.err
+}
+
+// check rejects the command substitutions, which are never run, and
+// the arithmetic expansions if they are disabled.
+func (t *expansion) check(word *syntax.Word) error {
+	var err error
+
+	syntax.Walk(word, func(node syntax.Node) bool {
+		switch node.(type) {
+		case *syntax.CmdSubst, *syntax.ProcSubst:
+			err = fmt.Errorf("%w: command substitution", ErrForbidden)
+		case *syntax.ArithmExp:
+			if t.opts.noArith {
+				err = fmt.Errorf("%w: arithmetic expansion", ErrForbidden)
+			}
+		}
+
+		return err == nil
+	})
+
+	return err
+}
+
+// coerce converts the value of a variable to a number or a boolean,
+// if it is one.
+func coerce(s string) interface{} {
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err == nil {
+		return i
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return f
+	}
+
+	return s
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
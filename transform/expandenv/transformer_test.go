@@ -48,3 +48,327 @@ func TestExpandEnvTransformer_Transform_WithDefaultOsGetenv(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, expanded["test"])
 }
+
+func TestExpandEnvTransformer_Transform_SpecialCharacters(t *testing.T) {
+	t.Parallel()
+
+	envs := map[string]string{
+		"PASSWORD": `p"a\ss", "admin": "true`,
+	}
+
+	transformer := NewTransformer(WithGetenv(func(s string) string {
+		return envs[s]
+	}))
+	values := map[string]interface{}{
+		"db": map[string]interface{}{
+			"password": "${PASSWORD}",
+			"port":     5432,
+		},
+	}
+
+	expanded, err := transformer.Transform(values)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"db": map[string]interface{}{
+			"password": `p"a\ss", "admin": "true`,
+			"port":     5432,
+		},
+	}, expanded)
+}
+
+func TestExpandEnvTransformer_Transform_WithCoerce(t *testing.T) {
+	t.Parallel()
+
+	envs := map[string]string{
+		"PORT":    "8080",
+		"RATIO":   "0.5",
+		"ENABLED": "true",
+		"NAME":    "api",
+		"INF":     "Inf",
+	}
+
+	transformer := NewTransformer(
+		WithGetenv(func(s string) string {
+			return envs[s]
+		}),
+		WithCoerce(),
+	)
+
+	tests := []struct {
+		value    string
+		expected interface{}
+	}{
+		{value: "${PORT}", expected: int64(8080)},
+		{value: "${RATIO}", expected: 0.5},
+		{value: "${ENABLED}", expected: true},
+		{value: "${TIMEOUT:-30}", expected: int64(30)},
+		{value: "${NAME}", expected: "api"},
+		{value: "${INF}", expected: "Inf"},
+		{value: "$PORT", expected: "8080"},
+		{value: ":${PORT}", expected: ":8080"},
+		{value: "${PORT}${PORT}", expected: "80808080"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+
+			expanded, err := transformer.Transform(map[string]interface{}{"value": test.value})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, expanded["value"])
+		})
+	}
+}
+
+func TestExpandEnvTransformer_Transform_WithExpandKeys(t *testing.T) {
+	t.Parallel()
+
+	envs := map[string]string{
+		"REGION": "eu",
+	}
+	getenv := func(s string) string {
+		return envs[s]
+	}
+
+	values := map[string]interface{}{
+		"replicas": map[string]interface{}{
+			"${REGION}": []interface{}{
+				map[string]interface{}{"${REGION}-addr": "${REGION}.example.com"},
+			},
+		},
+	}
+
+	expanded, err := NewTransformer(WithGetenv(getenv), WithExpandKeys()).Transform(values)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"replicas": map[string]interface{}{
+			"eu": []interface{}{
+				map[string]interface{}{"eu-addr": "eu.example.com"},
+			},
+		},
+	}, expanded)
+
+	expanded, err = NewTransformer(WithGetenv(getenv)).Transform(values)
+	require.NoError(t, err)
+	assert.Contains(t, expanded["replicas"], "${REGION}")
+
+	_, err = NewTransformer(WithGetenv(getenv), WithExpandKeys()).Transform(map[string]interface{}{
+		"eu":        1,
+		"${REGION}": 2,
+	})
+	require.ErrorIs(t, err, ErrDuplicateKey)
+}
+
+func TestExpandEnvTransformer_Transform_Errors(t *testing.T) {
+	t.Parallel()
+
+	envs := map[string]string{
+		"APP_PORT": "8080",
+		"HOME":     "/root",
+		"EMPTY":    "",
+	}
+	lookupEnv := func(s string) (string, bool) {
+		value, ok := envs[s]
+
+		return value, ok
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		opts  []Option
+		err   error
+	}{
+		{
+			name:  "strict unset",
+			value: "${APP_HOST}",
+			opts:  []Option{WithStrict()},
+			err:   ErrUnset,
+		},
+		{
+			name:  "not allowed",
+			value: "${HOME}/app",
+			opts:  []Option{WithAllowedPrefix("APP_")},
+			err:   ErrNotAllowed,
+		},
+		{
+			name:  "not in allowlist",
+			value: "${APP_PORT}",
+			opts:  []Option{WithAllowlist("APP_HOST")},
+			err:   ErrNotAllowed,
+		},
+		{
+			name:  "command substitution",
+			value: "$(cat /etc/passwd)",
+			err:   ErrForbidden,
+		},
+		{
+			name:  "arithmetic",
+			value: "$((APP_PORT + 1))",
+			opts:  []Option{WithoutArithmetic()},
+			err:   ErrForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := append([]Option{WithLookupEnv(lookupEnv)}, test.opts...)
+
+			_, err := NewTransformer(opts...).Transform(map[string]interface{}{"value": test.value})
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestExpandEnvTransformer_Transform_Strict(t *testing.T) {
+	t.Parallel()
+
+	envs := map[string]string{
+		"APP_PORT": "8080",
+		"EMPTY":    "",
+		"HOME":     "/root",
+	}
+
+	transformer := NewTransformer(
+		WithLookupEnv(func(s string) (string, bool) {
+			value, ok := envs[s]
+
+			return value, ok
+		}),
+		WithStrict(),
+		WithAllowedPrefix("APP_"),
+		WithAllowlist("EMPTY"),
+	)
+
+	expanded, err := transformer.Transform(map[string]interface{}{
+		"addr":  ":${APP_PORT}",
+		"host":  "${APP_HOST:-localhost}",
+		"empty": "${EMPTY}",
+		"next":  "$((APP_PORT + 1))",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"addr":  ":8080",
+		"host":  "localhost",
+		"empty": "",
+		"next":  "8081",
+	}, expanded)
+}
+
+func TestExpandEnvTransformer_Transform_WithReport(t *testing.T) {
+	t.Parallel()
+
+	envs := map[string]string{
+		"DB_HOST": "db",
+	}
+
+	var report Report
+
+	transformer := NewTransformer(
+		WithGetenv(func(s string) string {
+			return envs[s]
+		}),
+		WithReport(func(r Report) {
+			report = r
+		}),
+	)
+
+	_, err := transformer.Transform(map[string]interface{}{
+		"data": map[string]interface{}{
+			"database": map[string]interface{}{
+				"source": "${DB_USER:-root}@${DB_HOST}",
+			},
+			"redis": map[string]interface{}{
+				"addrs": []interface{}{"${DB_HOST}:6379"},
+			},
+		},
+		"name": "app",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, Report{
+		Variables: []Variable{
+			{Name: "DB_HOST", Set: true, Paths: []string{"data.database.source", "data.redis.addrs[0]"}},
+			{Name: "DB_USER", Set: false, Paths: []string{"data.database.source"}},
+		},
+	}, report)
+}
+
+func TestExpandEnvTransformer_Transform_EveryError(t *testing.T) {
+	t.Parallel()
+
+	var report Report
+
+	transformer := NewTransformer(
+		WithLookupEnv(func(s string) (string, bool) {
+			return "", false
+		}),
+		WithStrict(),
+		WithReport(func(r Report) {
+			report = r
+		}),
+	)
+
+	_, err := transformer.Transform(map[string]interface{}{
+		"server": map[string]interface{}{
+			"http": map[string]interface{}{
+				"addr":    "${A}",
+				"timeout": "${T}",
+			},
+			"grpc": map[string]interface{}{
+				"addr": "${B}",
+			},
+		},
+		"cmd": "$(id)",
+	})
+	require.ErrorIs(t, err, ErrUnset)
+	require.ErrorIs(t, err, ErrForbidden)
+	assert.EqualError(t, err, "expand cmd: expansion is forbidden: command substitution\n"+
+		"expand server.grpc.addr: variable is not set: B\n"+
+		"expand server.http.addr: variable is not set: A\n"+
+		"expand server.http.timeout: variable is not set: T")
+
+	assert.Equal(t, Report{
+		Variables: []Variable{
+			{Name: "A", Set: false, Paths: []string{"server.http.addr"}},
+			{Name: "B", Set: false, Paths: []string{"server.grpc.addr"}},
+			{Name: "T", Set: false, Paths: []string{"server.http.timeout"}},
+		},
+	}, report)
+}
+
+func TestExpandEnvTransformer_Transform_ReportDenied(t *testing.T) {
+	t.Parallel()
+
+	var report Report
+
+	transformer := NewTransformer(
+		WithLookupEnv(func(s string) (string, bool) {
+			return "value", true
+		}),
+		WithAllowedPrefix("APP_"),
+		WithReport(func(r Report) {
+			report = r
+		}),
+	)
+
+	_, err := transformer.Transform(map[string]interface{}{
+		"addr": "${APP_HOST}:${PORT}",
+		"home": "${HOME}",
+	})
+	require.ErrorIs(t, err, ErrNotAllowed)
+
+	assert.Equal(t, Report{
+		Variables: []Variable{
+			{Name: "APP_HOST", Set: true, Paths: []string{"addr"}},
+			{Name: "HOME", Denied: true, Paths: []string{"home"}},
+			{Name: "PORT", Denied: true, Paths: []string{"addr"}},
+		},
+	}, report)
+}
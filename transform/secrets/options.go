@@ -0,0 +1,26 @@
+package secrets
+
+// Option is transformer option.
+type Option func(*options)
+
+type options struct {
+	resolvers map[string]SecretResolver
+	backends  map[string]SecretResolver
+}
+
+// WithResolver sets the resolver of the references with the scheme, e.g.
+// `vault` for `vault://db`. The `file` and `env` schemes are resolved by
+// FileResolver and EnvResolver unless they are overridden.
+func WithResolver(scheme string, resolver SecretResolver) Option {
+	return func(opts *options) {
+		opts.resolvers[scheme] = resolver
+	}
+}
+
+// WithBackend sets the resolver of the `secret` references of the backend,
+// e.g. `vault` for `secret://vault/db#password`.
+func WithBackend(name string, resolver SecretResolver) Option {
+	return func(opts *options) {
+		opts.backends[name] = resolver
+	}
+}
@@ -0,0 +1,69 @@
+package secrets
+
+import (
+	"strings"
+)
+
+// SchemeSecret is the scheme of the references to a named backend,
+// e.g. `secret://vault/db#password`.
+const SchemeSecret = "secret"
+
+// Reference is a secret reference, e.g. `secret://vault/db#password`,
+// `file:///run/secrets/db` or `env://DB_PASS`.
+type Reference struct {
+	// Scheme is the reference scheme, e.g. `secret`, `file` or `env`.
+	Scheme string
+	// Backend is the backend of a `secret` reference, e.g. `vault`.
+	Backend string
+	// Key identifies the secret in its resolver, e.g. `db`,
+	// `/run/secrets/db` or `DB_PASS`.
+	Key string
+	// Field is the field of a secret holding a JSON object,
+	// e.g. `password`.
+	Field string
+}
+
+// parseReference parses the value as a reference with the scheme.
+func parseReference(value string) (Reference, bool) {
+	scheme, rest, ok := strings.Cut(value, "://")
+	if !ok || scheme == "" {
+		return Reference{}, false
+	}
+
+	ref := Reference{Scheme: scheme}
+	rest, ref.Field, _ = strings.Cut(rest, "#")
+
+	if scheme == SchemeSecret {
+		ref.Backend, ref.Key, _ = strings.Cut(rest, "/")
+	} else {
+		ref.Key = rest
+	}
+
+	return ref, true
+}
+
+// String returns the reference in its URL form.
+func (r Reference) String() string {
+	var builder strings.Builder
+
+	builder.WriteString(r.Scheme + "://")
+
+	if r.Scheme == SchemeSecret {
+		builder.WriteString(r.Backend + "/")
+	}
+
+	builder.WriteString(r.Key)
+
+	if r.Field != "" {
+		builder.WriteString("#" + r.Field)
+	}
+
+	return builder.String()
+}
+
+// secret returns the reference without its field, identifying the secret.
+func (r Reference) secret() Reference {
+	r.Field = ""
+
+	return r
+}
@@ -0,0 +1,187 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("secret not found")
+	ErrStatus     = errors.New("unexpected status")
+	ErrInvalidKey = errors.New("invalid key")
+)
+
+// SecretResolver resolves the secret references of a scheme or a backend.
+type SecretResolver interface {
+	// Resolve returns the secret value of the reference. The Field of
+	// the reference is extracted by the transformer.
+	Resolve(ctx context.Context, ref Reference) (string, error)
+}
+
+// FileResolver reads the secrets from files, e.g. `file:///run/secrets/db`.
+// The trailing newline of the file is removed.
+type FileResolver struct{}
+
+// NewFileResolver creates a new FileResolver.
+func NewFileResolver() *FileResolver {
+	return &FileResolver{}
+}
+
+func (r *FileResolver) Resolve(_ context.Context, ref Reference) (string, error) {
+	data, err := os.ReadFile(ref.Key)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNotFound
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("read file: %w", err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// EnvResolver reads the secrets from environment variables,
+// e.g. `env://DB_PASS`.
+type EnvResolver struct {
+	lookupEnv func(string) (string, bool)
+}
+
+// NewEnvResolver creates a new EnvResolver. The variables are looked up
+// with lookupEnv, or os.LookupEnv if it is nil.
+func NewEnvResolver(lookupEnv func(string) (string, bool)) *EnvResolver {
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+
+	return &EnvResolver{lookupEnv: lookupEnv}
+}
+
+func (r *EnvResolver) Resolve(_ context.Context, ref Reference) (string, error) {
+	value, ok := r.lookupEnv(ref.Key)
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return value, nil
+}
+
+// MemoryResolver resolves the secrets from a map by key. It is meant
+// for tests.
+type MemoryResolver struct {
+	secrets map[string]string
+}
+
+// NewMemoryResolver creates a new MemoryResolver of the secrets by key.
+func NewMemoryResolver(secrets map[string]string) *MemoryResolver {
+	return &MemoryResolver{secrets: secrets}
+}
+
+func (r *MemoryResolver) Resolve(_ context.Context, ref Reference) (string, error) {
+	value, ok := r.secrets[ref.Key]
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return value, nil
+}
+
+// HTTPResolver reads the secrets from an HTTP key-value store: the secret
+// of a key is the body of `GET <base URL>/<key>`, and a 404 status means
+// the secret does not exist. Every `/` separated segment of the key is
+// escaped, and the `.` and `..` segments are rejected, so a key cannot
+// reach another path or add a query.
+type HTTPResolver struct {
+	baseURL string
+	client  *http.Client
+	header  http.Header
+}
+
+// HTTPOption is HTTPResolver option.
+type HTTPOption func(*HTTPResolver)
+
+// WithHTTPClient sets the client of the requests. The default is
+// http.DefaultClient.
+func WithHTTPClient(client *http.Client) HTTPOption {
+	return func(r *HTTPResolver) {
+		r.client = client
+	}
+}
+
+// WithHTTPHeader adds a header to the requests, e.g. an authorization token.
+func WithHTTPHeader(key, value string) HTTPOption {
+	return func(r *HTTPResolver) {
+		r.header.Add(key, value)
+	}
+}
+
+// NewHTTPResolver creates a new HTTPResolver of the store at baseURL.
+func NewHTTPResolver(baseURL string, opts ...HTTPOption) *HTTPResolver {
+	r := &HTTPResolver{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  http.DefaultClient,
+		header:  make(http.Header),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func (r *HTTPResolver) Resolve(ctx context.Context, ref Reference) (string, error) {
+	keyPath, err := escapeKey(ref.Key)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.baseURL+"/"+keyPath, nil)
+	if err != nil {
+		return "", fmt.Errorf("new request: %w", err)
+	}
+
+	for key, values := range r.header {
+		req.Header[key] = values
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("get: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("get: %w %s", ErrStatus, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read body: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// escapeKey returns the URL path of the key with its segments escaped.
+func escapeKey(key string) (string, error) {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		if segment == "." || segment == ".." {
+			return "", fmt.Errorf("%w %q: %q segment", ErrInvalidKey, key, segment)
+		}
+
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/"), nil
+}
@@ -0,0 +1,76 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPResolver_Resolve(t *testing.T) {
+	t.Parallel()
+
+	store := map[string]string{"db/prod": `{"password": "http secret"}`}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		value, ok := store[r.URL.Path[1:]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(value))
+	}))
+	t.Cleanup(server.Close)
+
+	resolver := NewHTTPResolver(server.URL+"/", WithHTTPHeader("X-Token", "token"))
+
+	transformed, err := NewTransformer(WithBackend("kv", resolver)).Transform(map[string]interface{}{
+		"password": "secret://kv/db/prod#password",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "http secret", transformed["password"])
+
+	_, err = resolver.Resolve(context.Background(), Reference{Key: "db/missing"})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = NewHTTPResolver(server.URL).Resolve(context.Background(), Reference{Key: "db/prod"})
+	require.ErrorIs(t, err, ErrStatus)
+	assert.Contains(t, err.Error(), "403 Forbidden")
+}
+
+func TestHTTPResolver_ResolveEscapesKey(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+
+		_, _ = w.Write([]byte("secret"))
+	}))
+	t.Cleanup(server.Close)
+
+	resolver := NewHTTPResolver(server.URL)
+
+	secret, err := resolver.Resolve(context.Background(), Reference{Key: "db/a b?x=1#y"})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", secret)
+
+	for _, key := range []string{"../admin/token", "db/../../admin", "db/./prod"} {
+		_, err = resolver.Resolve(context.Background(), Reference{Key: key})
+		require.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+
+	assert.Equal(t, []string{"/db/a%20b%3Fx=1%23y"}, paths)
+}
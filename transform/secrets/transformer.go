@@ -0,0 +1,220 @@
+// Package secrets resolves the secret references of the configuration values,
+// e.g. `secret://vault/db#password`, `file:///run/secrets/db` or
+// `env://DB_PASS`, so the configuration files hold no plaintext secrets.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	ErrUnknownBackend = errors.New("unknown backend")
+	ErrNoField        = errors.New("no field")
+)
+
+// Transformer replaces the string values which are secret references with
+// the resolved secrets. A value is a reference if it starts with
+// `secret://` or the scheme of a resolver followed by `://`; the other
+// values are left as is.
+//
+// A reference with a field, e.g. `secret://vault/db#password`, selects
+// the field of a secret holding a JSON object. Every secret is resolved
+// once per transformation, even if it is referenced by several values.
+type Transformer struct {
+	opts options
+}
+
+// NewTransformer creates a new Transformer.
+func NewTransformer(opts ...Option) *Transformer {
+	confOpts := options{
+		resolvers: map[string]SecretResolver{
+			"file": NewFileResolver(),
+			"env":  NewEnvResolver(nil),
+		},
+		backends: make(map[string]SecretResolver),
+	}
+
+	for _, opt := range opts {
+		opt(&confOpts)
+	}
+
+	return &Transformer{
+		opts: confOpts,
+	}
+}
+
+func (t *Transformer) Transform(values map[string]interface{}) (map[string]interface{}, error) {
+	return t.TransformContext(context.Background(), values)
+}
+
+// TransformContext resolves the references with the context. All the
+// references are resolved, the values are returned with the errors of
+// the failed references joined with errors.Join.
+func (t *Transformer) TransformContext(
+	ctx context.Context,
+	values map[string]interface{},
+) (map[string]interface{}, error) {
+	r := &resolution{
+		transformer: t,
+		cache:       make(map[Reference]string),
+	}
+
+	resolved, ok := r.value(ctx, "", values).(map[string]interface{})
+	if !ok {
+		resolved = values
+	}
+
+	if len(r.errs) > 0 {
+		return resolved, errors.Join(r.errs...)
+	}
+
+	return resolved, nil
+}
+
+func (t *Transformer) Name() string {
+	return "secrets"
+}
+
+// resolver returns the resolver of the reference.
+func (t *Transformer) resolver(ref Reference) (SecretResolver, bool) {
+	if ref.Scheme == SchemeSecret {
+		resolver, ok := t.opts.backends[ref.Backend]
+
+		return resolver, ok
+	}
+
+	resolver, ok := t.opts.resolvers[ref.Scheme]
+
+	return resolver, ok
+}
+
+// resolution is a single transformation with its cache and errors.
+type resolution struct {
+	transformer *Transformer
+	cache       map[Reference]string
+	errs        []error
+}
+
+func (r *resolution) value(ctx context.Context, path string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			resolved[key] = r.value(ctx, joinPath(path, key), elem)
+		}
+
+		return resolved
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, elem := range v {
+			resolved[i] = r.value(ctx, path+"["+strconv.Itoa(i)+"]", elem)
+		}
+
+		return resolved
+	case string:
+		secret, err := r.string(ctx, v)
+		if err != nil {
+			r.errs = append(r.errs, &valueError{path: path, err: err})
+
+			return v
+		}
+
+		return secret
+	default:
+		return value
+	}
+}
+
+// string returns the secret of the value if it is a reference, or the value.
+func (r *resolution) string(ctx context.Context, value string) (string, error) {
+	ref, ok := parseReference(value)
+	if !ok {
+		return value, nil
+	}
+
+	resolver, ok := r.transformer.resolver(ref)
+	if !ok {
+		if ref.Scheme == SchemeSecret {
+			return "", fmt.Errorf("resolve %s: %w %q", ref, ErrUnknownBackend, ref.Backend)
+		}
+
+		return value, nil
+	}
+
+	secret, ok := r.cache[ref.secret()]
+	if !ok {
+		var err error
+
+		secret, err = resolver.Resolve(ctx, ref.secret())
+		if err != nil {
+			return "", fmt.Errorf("resolve %s: %w", ref, err)
+		}
+
+		r.cache[ref.secret()] = secret
+	}
+
+	if ref.Field == "" {
+		return secret, nil
+	}
+
+	return field(ref, secret)
+}
+
+// field returns the field of the secret holding a JSON object. String
+// fields are returned as is, other values as JSON.
+func field(ref Reference, secret string) (string, error) {
+	var fields map[string]interface{}
+
+	err := json.Unmarshal([]byte(secret), &fields)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: json unmarshal secret: %w", ref, err)
+	}
+
+	value, ok := fields[ref.Field]
+	if !ok {
+		return "", fmt.Errorf("resolve %s: %w %q", ref, ErrNoField, ref.Field)
+	}
+
+	s, ok := value.(string)
+	if ok {
+		return s, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: json marshal field: %w", ref, err)
+	}
+
+	return string(data), nil
+}
+
+// valueError is the error of the value at a path.
+type valueError struct {
+	path string
+	err  error
+}
+
+func (e *valueError) Error() string {
+	return e.path + ": " + e.err.Error()
+}
+
+// ValuePath returns the dotted path of the value.
+func (e *valueError) ValuePath() string {
+	return e.path
+}
+
+func (e *valueError) Unwrap() error {
+	return e.err
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
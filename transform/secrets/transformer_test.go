@@ -0,0 +1,151 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingResolver counts the resolutions of every key.
+type countingResolver struct {
+	SecretResolver
+
+	counts map[string]int
+}
+
+func (r *countingResolver) Resolve(ctx context.Context, ref Reference) (string, error) {
+	r.counts[ref.Key]++
+
+	return r.SecretResolver.Resolve(ctx, ref)
+}
+
+func TestTransformer_Transform(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "db")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file secret\n"), 0o600))
+
+	envs := map[string]string{"DB_PASS": "env secret"}
+
+	vault := &countingResolver{
+		SecretResolver: NewMemoryResolver(map[string]string{
+			"db": `{"user": "admin", "password": "vault secret", "port": 5432}`,
+		}),
+		counts: make(map[string]int),
+	}
+
+	transformer := NewTransformer(
+		WithBackend("vault", vault),
+		WithResolver("env", NewEnvResolver(func(key string) (string, bool) {
+			value, ok := envs[key]
+
+			return value, ok
+		})),
+	)
+
+	values := map[string]interface{}{
+		"database": map[string]interface{}{
+			"user":     "secret://vault/db#user",
+			"password": "secret://vault/db#password",
+			"port":     "secret://vault/db#port",
+		},
+		"file":  "file://" + secretFile,
+		"env":   []interface{}{"env://DB_PASS", 1},
+		"url":   "https://example.com",
+		"plain": "value",
+	}
+
+	transformed, err := transformer.Transform(values)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"database": map[string]interface{}{
+			"user":     "admin",
+			"password": "vault secret",
+			"port":     "5432",
+		},
+		"file":  "file secret",
+		"env":   []interface{}{"env secret", 1},
+		"url":   "https://example.com",
+		"plain": "value",
+	}, transformed)
+	assert.Equal(t, map[string]int{"db": 1}, vault.counts)
+	assert.Equal(t, "secret://vault/db#user", values["database"].(map[string]interface{})["user"])
+}
+
+func TestTransformer_TransformErrors(t *testing.T) {
+	t.Parallel()
+
+	transformer := NewTransformer(
+		WithBackend("vault", NewMemoryResolver(map[string]string{"db": `{"user": "admin"}`})),
+		WithResolver("env", NewEnvResolver(func(string) (string, bool) {
+			return "", false
+		})),
+	)
+
+	_, err := transformer.Transform(map[string]interface{}{
+		"a": "secret://vault/db#password",
+		"b": "secret://vault/missing",
+		"c": "secret://aws/db",
+		"d": []interface{}{"env://DB_PASS"},
+	})
+	require.Error(t, err)
+
+	assert.ErrorIs(t, err, ErrNoField)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, err, ErrUnknownBackend)
+	assert.Contains(t, err.Error(), `a: resolve secret://vault/db#password: no field "password"`)
+	assert.Contains(t, err.Error(), `b: resolve secret://vault/missing: secret not found`)
+	assert.Contains(t, err.Error(), `c: resolve secret://aws/db: unknown backend "aws"`)
+	assert.Contains(t, err.Error(), `d[0]: resolve env://DB_PASS: secret not found`)
+}
+
+func TestParseReference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value    string
+		expected Reference
+		ok       bool
+	}{
+		{
+			value:    "secret://vault/db/prod#password",
+			expected: Reference{Scheme: "secret", Backend: "vault", Key: "db/prod", Field: "password"},
+			ok:       true,
+		},
+		{
+			value:    "file:///run/secrets/db",
+			expected: Reference{Scheme: "file", Key: "/run/secrets/db"},
+			ok:       true,
+		},
+		{
+			value:    "env://DB_PASS",
+			expected: Reference{Scheme: "env", Key: "DB_PASS"},
+			ok:       true,
+		},
+		{
+			value: "root:root@tcp(127.0.0.1:3306)/test",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+
+			ref, ok := parseReference(test.value)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, ref)
+
+			if ok {
+				assert.Equal(t, test.value, ref.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package protoconf
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrNotLoaded = errors.New("config is not loaded")
+
+// TypedLoader loads validated configuration snapshots of type T.
+//
+// The snapshot returned by Current is shared between the callers and
+// must not be modified.
+type TypedLoader[T proto.Message] struct {
+	loader  *ConfigLoader
+	current atomic.Pointer[T]
+}
+
+// NewTyped creates a new TypedLoader.
+func NewTyped[T proto.Message](opts ...Option) (*TypedLoader[T], error) {
+	loader, err := New(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	typed := &TypedLoader[T]{
+		loader: loader,
+	}
+
+	// the snapshot is replaced before the other subscribers are called
+	loader.OnChange(func(_, newMessage proto.Message) {
+		message, ok := newMessage.(T)
+		if ok {
+			typed.current.Store(&message)
+		}
+	})
+
+	return typed, nil
+}
+
+// Current returns the current configuration snapshot. It returns
+// the zero value of T if the configuration is not loaded yet.
+func (l *TypedLoader[T]) Current() T {
+	current := l.current.Load()
+	if current == nil {
+		var zero T
+
+		return zero
+	}
+
+	return *current
+}
+
+// Load loads, scans and validates the configuration. The current snapshot
+// is replaced only if the new configuration is valid.
+func (l *TypedLoader[T]) Load(ctx context.Context) error {
+	l.loader.reloadMu.Lock()
+	defer l.loader.reloadMu.Unlock()
+
+	config, err := l.loader.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	message := l.newMessage()
+
+	err = l.loader.scan(config, message)
+	if err != nil {
+		return err
+	}
+
+	l.loader.mu.Lock()
+	l.loader.values = config.values
+	l.loader.origins = config.origins
+	l.loader.errs = config.errs
+	l.loader.current = message
+	l.loader.mu.Unlock()
+
+	l.current.Store(&message)
+
+	return nil
+}
+
+// OnChange subscribes fn to the configuration changes detected by Watch.
+func (l *TypedLoader[T]) OnChange(fn func(oldMessage, newMessage T)) {
+	l.loader.OnChange(func(oldMessage, newMessage proto.Message) {
+		oldTyped, _ := oldMessage.(T)
+		newTyped, _ := newMessage.(T)
+
+		fn(oldTyped, newTyped)
+	})
+}
+
+// Watch starts watching the providers and replaces the current snapshot on
+// every valid change, see ConfigLoader.Watch. The configuration must be
+// loaded before.
+func (l *TypedLoader[T]) Watch() error {
+	current := l.current.Load()
+	if current == nil {
+		return ErrNotLoaded
+	}
+
+	return l.loader.Watch(*current)
+}
+
+// Loader returns the underlying ConfigLoader.
+func (l *TypedLoader[T]) Loader() *ConfigLoader {
+	return l.loader
+}
+
+func (l *TypedLoader[T]) newMessage() T {
+	var zero T
+
+	message, _ := zero.ProtoReflect().New().Interface().(T)
+
+	return message
+}
@@ -0,0 +1,109 @@
+package protoconf
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+)
+
+func TestTypedLoader_Load(t *testing.T) {
+	t.Parallel()
+
+	loader, err := NewTyped[*v1.Config](
+		WithProvider(file.Provider("conf/config.yaml")),
+		WithParser(yaml.Parser()),
+	)
+	require.NoError(t, err)
+	assert.Nil(t, loader.Current())
+
+	err = loader.Load(context.Background())
+	require.NoError(t, err)
+
+	cfg := loader.Current()
+	require.NotNil(t, cfg)
+	assert.Equal(t, "127.0.0.1:8080", cfg.GetServer().GetHttp().GetAddr())
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.Equal(t, "0.0.0.0:9000", loader.Current().GetServer().GetGrpc().GetAddr())
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestTypedLoader_LoadKeepsSnapshotOnInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	provider := &memoryProvider{
+		data: []byte("server: {http: {addr: 127.0.0.1:8080}, grpc: {addr: 0.0.0.0:9000}}\ndata: {}"),
+	}
+
+	loader, err := NewTyped[*v1.ConfigWithValidate](
+		WithProvider(provider),
+		WithParser(yaml.Parser()),
+	)
+	require.NoError(t, err)
+	require.NoError(t, loader.Load(context.Background()))
+
+	snapshot := loader.Current()
+
+	provider.data = []byte("server: {http: {}, grpc: {addr: 0.0.0.0:9000}}\ndata: {}")
+	require.Error(t, loader.Load(context.Background()))
+	assert.Same(t, snapshot, loader.Current())
+}
+
+func TestTypedLoader_LoadWithCanceledContext(t *testing.T) {
+	t.Parallel()
+
+	loader, err := NewTyped[*v1.Config](
+		WithProvider(NewMockProvider(t)),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = loader.Load(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTypedLoader_Watch(t *testing.T) {
+	t.Parallel()
+
+	provider := &memoryProvider{
+		data: []byte("server: {http: {addr: 127.0.0.1:8080}}"),
+	}
+
+	loader, err := NewTyped[*v1.Config](
+		WithProvider(provider),
+		WithParser(yaml.Parser()),
+	)
+	require.NoError(t, err)
+	require.ErrorIs(t, loader.Watch(), ErrNotLoaded)
+	require.NoError(t, loader.Load(context.Background()))
+
+	var changed *v1.Config
+
+	loader.OnChange(func(_, newMessage *v1.Config) {
+		changed = newMessage
+	})
+	require.NoError(t, loader.Watch())
+
+	provider.update("server: {http: {addr: 127.0.0.1:9090}}")
+	assert.Equal(t, "127.0.0.1:9090", loader.Current().GetServer().GetHttp().GetAddr())
+	assert.Same(t, changed, loader.Current())
+}
@@ -0,0 +1,120 @@
+package protoconf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrNoWatcher = errors.New("no provider implements Watcher")
+
+// Watcher is implemented by providers that can notify about configuration
+// changes, e.g. koanf file.Provider.
+type Watcher interface {
+	// Watch calls cb on every change of the configuration source.
+	Watch(cb func(event interface{}, err error)) error
+}
+
+// ChangeFunc is called with the previous and the new configuration
+// after a successful reload.
+type ChangeFunc func(oldMessage, newMessage proto.Message)
+
+// OnChange subscribes fn to the configuration changes detected by Watch.
+func (c *ConfigLoader) OnChange(fn ChangeFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.subscribers = append(c.subscribers, fn)
+}
+
+// Watch starts watching the providers implementing Watcher. The message must
+// hold the current configuration, e.g. the message passed to Scan.
+//
+// On every change the configuration is loaded, transformed and scanned into
+// a new message of the same type. The subscribers are called with the
+// previous and the new message only if the new configuration is valid,
+// otherwise the error is passed to the handler set by WithWatchErrorHandler
+// and the previous configuration is kept.
+func (c *ConfigLoader) Watch(message proto.Message) error {
+	c.mu.Lock()
+	c.current = message
+	c.mu.Unlock()
+
+	var watching bool
+
+	for i, l := range c.opts.layers {
+		watcher, ok := l.provider.(Watcher)
+		if !ok {
+			continue
+		}
+
+		i := i
+
+		err := watcher.Watch(func(_ interface{}, err error) {
+			if err != nil {
+				c.handleWatchError(fmt.Errorf("watch layer %d: %w", i, err))
+
+				return
+			}
+
+			c.reload()
+		})
+		if err != nil {
+			return fmt.Errorf("watch layer %d: %w", i, err)
+		}
+
+		watching = true
+	}
+
+	if !watching {
+		return ErrNoWatcher
+	}
+
+	return nil
+}
+
+func (c *ConfigLoader) reload() {
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+
+	config, err := c.load(context.Background())
+	if err != nil {
+		c.handleWatchError(fmt.Errorf("reload: %w", err))
+
+		return
+	}
+
+	c.mu.RLock()
+	oldMessage := c.current
+	c.mu.RUnlock()
+
+	newMessage := oldMessage.ProtoReflect().New().Interface()
+
+	err = c.scan(config, newMessage)
+	if err != nil {
+		c.handleWatchError(fmt.Errorf("reload: %w", err))
+
+		return
+	}
+
+	c.mu.Lock()
+	c.values = config.values
+	c.origins = config.origins
+	c.errs = config.errs
+	c.current = newMessage
+	subscribers := make([]ChangeFunc, len(c.subscribers))
+	copy(subscribers, c.subscribers)
+	c.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(oldMessage, newMessage)
+	}
+}
+
+func (c *ConfigLoader) handleWatchError(err error) {
+	if c.opts.watchErrorHandler != nil {
+		c.opts.watchErrorHandler(err)
+	}
+}
@@ -0,0 +1,108 @@
+package protoconf
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/gosynergy/protoconf/conf/v1"
+)
+
+type memoryProvider struct {
+	mu   sync.Mutex
+	data []byte
+	cb   func(event interface{}, err error)
+}
+
+func (p *memoryProvider) ReadBytes() ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.data, nil
+}
+
+func (p *memoryProvider) Read() (map[string]interface{}, error) {
+	return nil, nil //nolint:nilnil
+}
+
+func (p *memoryProvider) Watch(cb func(event interface{}, err error)) error {
+	p.cb = cb
+
+	return nil
+}
+
+func (p *memoryProvider) update(data string) {
+	p.mu.Lock()
+	p.data = []byte(data)
+	p.mu.Unlock()
+
+	p.cb(nil, nil)
+}
+
+func TestConfigLoader_Watch(t *testing.T) {
+	t.Parallel()
+
+	provider := &memoryProvider{
+		data: []byte("server: {http: {addr: 127.0.0.1:8080}, grpc: {addr: 0.0.0.0:9000}}\ndata: {}"),
+	}
+
+	var watchErrs []error
+
+	loader, err := New(
+		WithProvider(provider),
+		WithParser(yaml.Parser()),
+		WithWatchErrorHandler(func(err error) {
+			watchErrs = append(watchErrs, err)
+		}),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, loader.Load())
+
+	var cfg v1.ConfigWithValidate
+	require.NoError(t, loader.Scan(&cfg))
+
+	var changes [][2]proto.Message
+
+	loader.OnChange(func(oldMessage, newMessage proto.Message) {
+		changes = append(changes, [2]proto.Message{oldMessage, newMessage})
+	})
+	require.NoError(t, loader.Watch(&cfg))
+
+	provider.update("server: {http: {addr: 127.0.0.1:9090}, grpc: {addr: 0.0.0.0:9000}}\ndata: {}")
+	require.Len(t, changes, 1)
+	assert.Same(t, &cfg, changes[0][0])
+
+	newCfg, ok := changes[0][1].(*v1.ConfigWithValidate)
+	require.True(t, ok)
+	assert.Equal(t, "127.0.0.1:9090", newCfg.GetServer().GetHttp().GetAddr())
+
+	// invalid configuration is not propagated
+	provider.update("server: {http: {}, grpc: {addr: 0.0.0.0:9000}}\ndata: {}")
+	assert.Len(t, changes, 1)
+	require.Len(t, watchErrs, 1)
+
+	var scanned v1.ConfigWithValidate
+	require.NoError(t, loader.Scan(&scanned))
+	assert.Equal(t, "127.0.0.1:9090", scanned.GetServer().GetHttp().GetAddr())
+
+	provider.update("server: {http: {addr: 127.0.0.1:7070}, grpc: {addr: 0.0.0.0:9000}}\ndata: {}")
+	require.Len(t, changes, 2)
+	assert.Same(t, newCfg, changes[1][0])
+}
+
+func TestConfigLoader_WatchWithoutWatcher(t *testing.T) {
+	t.Parallel()
+
+	loader, err := New(
+		WithProvider(NewMockProvider(t)),
+	)
+	require.NoError(t, err)
+
+	err = loader.Watch(&v1.Config{})
+	require.ErrorIs(t, err, ErrNoWatcher)
+}